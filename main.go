@@ -2,14 +2,18 @@ package main
 
 import (
 	"fmt"
+	"io"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"availability-checker/pkg/checker"
 	"availability-checker/pkg/credentialprovider"
-	"availability-checker/pkg/database"
 	"availability-checker/pkg/k8s"
+	"availability-checker/pkg/plugin"
 	"availability-checker/pkg/server"
 
 	_ "github.com/lib/pq"
@@ -17,61 +21,156 @@ import (
 )
 
 type Config struct {
-	Checkers []struct {
-		Type   string
-		URL    string `yaml:",omitempty"`
-		Server string `yaml:"server,omitempty"`
-		Port   string `yaml:"port,omitempty"`
-	}
+	PluginDir string `yaml:"pluginDir,omitempty"`
+	// Checkers are decoded by the factory registered for their type
+	Checkers    []map[string]interface{}    `yaml:"checkers"`
+	Maintenance []checker.MaintenanceWindow `yaml:"maintenance"`
+	Concurrency checker.Limits              `yaml:"concurrency"`
 }
 
+const (
+	configFile = "config.yaml"
+	// configPollInterval is how often config.yaml is checked for changes
+	configPollInterval = 5 * time.Second
+)
+
 func main() {
 	credProvider, err := credentialProviderAuth()
 	if err != nil {
 		log.Fatalf("Error authenticating credential provider: %v", err)
 	}
-	data, err := os.ReadFile("config.yaml")
+	config, err := readConfig(configFile)
 	if err != nil {
 		log.Fatalf("Error reading config file: %v", err)
 	}
-	var config Config
-	yaml.Unmarshal(data, &config)
+
+	pluginDir := config.PluginDir
+	if pluginDir == "" {
+		pluginDir = "plugins"
+	}
+	plugins, err := plugin.Discover(pluginDir)
+	if err != nil {
+		log.Fatalf("Error loading plugins: %v", err)
+	}
+	for _, p := range plugins {
+		if checker.Registered(p.Type) {
+			log.Printf("Ignoring plugin %s, checker type %s is built in\n", p.Path, p.Type)
+			continue
+		}
+		checker.Register(p.Type, checker.PluginFactory(p))
+	}
 
 	k8sclient, err := k8s.NewK8sClient()
 	if err != nil {
 		log.Fatalf("Error creating k8s client: %v", err)
 	}
-	checkers := make([]checker.Checker, len(config.Checkers))
-	for i, confChecker := range config.Checkers {
-		switch confChecker.Type {
-		case "http":
-			checkers[i] = &checker.HttpChecker{URL: confChecker.URL}
-		case "postgres":
-			checkers[i] = &checker.PostgresChecker{
-				Server:             confChecker.Server,
-				Port:               confChecker.Port,
-				DBConnection:       &database.SQLDBConnection{},
-				CredentialProvider: credProvider,
-				K8sClient:          *k8sclient,
-			}
-		case "mysql":
-			checkers[i] = &checker.MySQLChecker{
-				Server:             confChecker.Server,
-				Port:               confChecker.Port,
-				DBConnection:       &database.SQLDBConnection{},
-				CredentialProvider: credProvider,
-				K8sClient:          *k8sclient,
-			}
-		}
+	deps := checker.Dependencies{CredentialProvider: credProvider, K8sClient: k8sclient}
+
+	entries, err := newEntries(config, deps)
+	if err != nil {
+		log.Fatalf("Error in %v", err)
 	}
 
-	serverInstance := server.NewServer(checkers, "template.gotmpl")
+	serverInstance, err := server.NewServer(entries, config.Maintenance, config.Concurrency, "template.gotmpl")
+	if err != nil {
+		log.Fatalf("Error creating server: %v", err)
+	}
 
 	go serverInstance.StartChecking()
+	go watchConfig(configFile, func() {
+		err := reloadConfig(serverInstance, config.PluginDir, deps)
+		if err != nil {
+			log.Printf("Error reloading config, keeping the last good one: %v\n", err)
+		}
+	})
 
 	http.ListenAndServe(":8080", serverInstance)
 }
 
+func readConfig(path string) (Config, error) {
+	var config Config
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, err
+	}
+	err = yaml.UnmarshalStrict(data, &config)
+	return config, err
+}
+
+func newEntries(config Config, deps checker.Dependencies) ([]checker.Entry, error) {
+	entries := make([]checker.Entry, len(config.Checkers))
+	for i, entry := range config.Checkers {
+		var err error
+		entries[i], err = checker.New(entry, deps)
+		if err != nil {
+			closeEntries(entries[:i])
+			return nil, fmt.Errorf("checker #%d: %v", i+1, err)
+		}
+	}
+	return entries, nil
+}
+
+// closeEntries releases the resources of entries that won't be used.
+func closeEntries(entries []checker.Entry) {
+	for _, e := range entries {
+		if c, ok := e.Checker.(io.Closer); ok {
+			c.Close()
+		}
+	}
+}
+
+// reloadConfig applies config.yaml to the running server. Plugins are only
+// discovered at startup, so pluginDir changes require a restart.
+func reloadConfig(s *server.Server, pluginDir string, deps checker.Dependencies) error {
+	config, err := readConfig(configFile)
+	if err != nil {
+		return err
+	}
+	if config.PluginDir != pluginDir {
+		log.Printf("Ignoring pluginDir change, plugins are only loaded at startup\n")
+	}
+	entries, err := newEntries(config, deps)
+	if err != nil {
+		return err
+	}
+	// Reload closes the entries it doesn't use, also when it rejects them
+	return s.Reload(entries, config.Maintenance, config.Concurrency)
+}
+
+// watchConfig calls reload when the modification time of the config file
+// changes and on SIGHUP.
+func watchConfig(path string, reload func()) {
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	ticker := time.NewTicker(configPollInterval)
+	defer ticker.Stop()
+
+	lastModified := modTime(path)
+	for {
+		select {
+		case <-hangup:
+			log.Printf("Received SIGHUP, reloading %s\n", path)
+		case <-ticker.C:
+			if modTime(path).Equal(lastModified) {
+				continue
+			}
+			log.Printf("%s changed, reloading\n", path)
+		}
+		lastModified = modTime(path)
+		reload()
+	}
+}
+
+// modTime returns the modification time of a file, or the zero time when it
+// can't be read.
+func modTime(path string) time.Time {
+	info, err := os.Stat(path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
 func credentialProviderAuth() (credentialprovider.CredentialProvider, error) {
 	var credProvider credentialprovider.CredentialProvider
 	if os.Getenv("AZURE_KEYVAULT") != "" {
@@ -0,0 +1,172 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const defaultAmqpTimeout = 10 * time.Second
+
+// AmqpChecker opens a connection and a channel to an AMQP 0-9-1 broker such
+// as RabbitMQ and optionally asserts on the depth and consumers of a queue.
+type AmqpChecker struct {
+	Server string
+	Port   string
+	Vhost  string
+	TLS    bool
+	// Queue is passively declared to confirm it exists, empty skips the
+	// queue assertions.
+	Queue string
+	// MaxQueueDepth is the maximum number of ready messages, zero disables
+	// the check.
+	MaxQueueDepth      int
+	MinConsumers       int
+	Timeout            time.Duration
+	CredentialProvider credentialprovider.CredentialProvider
+}
+
+func (c *AmqpChecker) Name() string {
+	if c.Queue == "" {
+		return fmt.Sprintf("AMQP: %s:%s", c.Server, c.Port)
+	}
+	return fmt.Sprintf("AMQP: %s:%s/%s", c.Server, c.Port, c.Queue)
+}
+
+func (c *AmqpChecker) Target() string {
+	return c.Server
+}
+
+func (c *AmqpChecker) Check() (bool, error) {
+	user, pwd, err := c.CredentialProvider.GetCredentials("amqp")
+	if err != nil {
+		return false, fmt.Errorf("error getting credentials: %v", err)
+	}
+
+	if user == "" || pwd == "" {
+		return false, errors.New("empty username or password")
+	}
+
+	port, err := strconv.Atoi(c.Port)
+	if err != nil {
+		return false, fmt.Errorf("invalid port %q: %v", c.Port, err)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultAmqpTimeout
+	}
+
+	vhost := c.Vhost
+	if vhost == "" {
+		vhost = "/"
+	}
+
+	uri := amqp.URI{
+		Scheme:   "amqp",
+		Host:     c.Server,
+		Port:     port,
+		Username: user,
+		Password: pwd,
+		Vhost:    vhost,
+	}
+	config := amqp.Config{Dial: amqp.DefaultDial(timeout)}
+	if c.TLS {
+		uri.Scheme = "amqps"
+		config.TLSClientConfig = &tls.Config{ServerName: c.Server}
+	}
+
+	conn, err := amqp.DialConfig(uri.String(), config)
+	if err != nil {
+		fmt.Printf("Error opening connection: %v\n", err)
+		return false, err
+	}
+	defer conn.Close()
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return false, fmt.Errorf("error opening channel: %v", err)
+	}
+	defer ch.Close()
+
+	if c.Queue == "" {
+		return true, nil
+	}
+
+	queue, err := ch.QueueDeclarePassive(c.Queue, false, false, false, false, nil)
+	if err != nil {
+		return false, fmt.Errorf("error declaring queue %s: %v", c.Queue, err)
+	}
+
+	err = c.checkQueue(queue)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkQueue asserts the configured thresholds against a declared queue.
+func (c *AmqpChecker) checkQueue(queue amqp.Queue) error {
+	if c.MaxQueueDepth > 0 && queue.Messages > c.MaxQueueDepth {
+		return fmt.Errorf("queue %s has %d messages, exceeds %d", queue.Name, queue.Messages, c.MaxQueueDepth)
+	}
+
+	if queue.Consumers < c.MinConsumers {
+		return fmt.Errorf("queue %s has %d consumers, expected at least %d", queue.Name, queue.Consumers, c.MinConsumers)
+	}
+
+	return nil
+}
+
+func (c *AmqpChecker) Fix() error {
+	return nil
+}
+
+func (c *AmqpChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("amqp", newAmqpChecker)
+}
+
+type amqpConfig struct {
+	Server        string        `yaml:"server"`
+	Port          string        `yaml:"port"`
+	Vhost         string        `yaml:"vhost"`
+	TLS           bool          `yaml:"tls"`
+	Queue         string        `yaml:"queue"`
+	MaxQueueDepth int           `yaml:"maxQueueDepth"`
+	MinConsumers  int           `yaml:"minConsumers"`
+	Timeout       time.Duration `yaml:"timeout"`
+}
+
+func newAmqpChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config amqpConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server, "port": config.Port})
+	if err != nil {
+		return nil, err
+	}
+
+	return &AmqpChecker{
+		Server:             config.Server,
+		Port:               config.Port,
+		Vhost:              config.Vhost,
+		TLS:                config.TLS,
+		Queue:              config.Queue,
+		MaxQueueDepth:      config.MaxQueueDepth,
+		MinConsumers:       config.MinConsumers,
+		Timeout:            config.Timeout,
+		CredentialProvider: deps.CredentialProvider,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAmqpChecker_CheckUnreachable(t *testing.T) {
+	checker := AmqpChecker{
+		Server:             "127.0.0.1",
+		Port:               "1",
+		Timeout:            time.Second,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestAmqpChecker_CheckInvalidPort(t *testing.T) {
+	checker := AmqpChecker{
+		Server:             "127.0.0.1",
+		Port:               "amqp",
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestAmqpChecker_CheckQueue(t *testing.T) {
+	queue := amqp.Queue{Name: "orders", Messages: 50, Consumers: 2}
+
+	// Test cases
+	testCases := []struct {
+		name          string
+		maxQueueDepth int
+		minConsumers  int
+		expectErr     bool
+	}{
+		{
+			name:      "no thresholds",
+			expectErr: false,
+		},
+		{
+			name:          "depth under threshold",
+			maxQueueDepth: 100,
+			expectErr:     false,
+		},
+		{
+			name:          "depth over threshold",
+			maxQueueDepth: 10,
+			expectErr:     true,
+		},
+		{
+			name:         "enough consumers",
+			minConsumers: 2,
+			expectErr:    false,
+		},
+		{
+			name:         "not enough consumers",
+			minConsumers: 3,
+			expectErr:    true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := AmqpChecker{
+				MaxQueueDepth: tc.maxQueueDepth,
+				MinConsumers:  tc.minConsumers,
+			}
+			err := checker.checkQueue(queue)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestAmqpChecker_Name(t *testing.T) {
+	checker := AmqpChecker{
+		Server: "rabbitmq.net",
+		Port:   "5672",
+	}
+	assert.Equal(t, "AMQP: rabbitmq.net:5672", checker.Name())
+
+	checker.Queue = "orders"
+	assert.Equal(t, "AMQP: rabbitmq.net:5672/orders", checker.Name())
+}
+
+func TestAmqpChecker_IsFixable(t *testing.T) {
+	checker := AmqpChecker{}
+	assert.False(t, checker.IsFixable())
+}
@@ -11,9 +11,98 @@ type Checker interface {
 	IsFixable() bool
 }
 
+// Detailer is implemented by checkers that report structured details about
+// their last check, such as replication lag or connection counts.
+type Detailer interface {
+	Details() map[string]string
+}
+
+// Messager is implemented by checkers that report a human readable message
+// about their last check, such as the output of a script.
+type Messager interface {
+	Message() string
+}
+
+// Targeter is implemented by checkers that connect to a single host. The
+// host is used to limit the number of concurrent checks against it, it is
+// empty when unknown.
+type Targeter interface {
+	Target() string
+}
+
+// Aggregator is implemented by checkers whose status is derived from the
+// latest results of other checkers, referenced by name. They are run after
+// the checkers they reference, with those results passed to SetResults.
+type Aggregator interface {
+	References() []string
+	SetResults(results map[string]CheckResult)
+	// Children returns the results the last check was based on.
+	Children() []CheckResult
+}
+
+// DegradedError is returned by Check when a service works but not at full
+// capacity, it is reported as degraded rather than unavailable.
+type DegradedError struct {
+	Reason string
+}
+
+func (e *DegradedError) Error() string {
+	return "degraded: " + e.Reason
+}
+
 type CheckResult struct {
-	Name        string
-	Status      bool
-	LastChecked time.Time
-	IsFixable   bool
+	Name        string            `json:"name"`
+	Type        string            `json:"type"`
+	Group       string            `json:"group,omitempty"`
+	Tags        []string          `json:"tags,omitempty"`
+	Status      bool              `json:"status"`
+	Degraded    bool              `json:"degraded"`
+	LastChecked time.Time         `json:"lastChecked"`
+	IsFixable   bool              `json:"isFixable"`
+	Message     string            `json:"message,omitempty"`
+	Details     map[string]string `json:"details,omitempty"`
+	Children    []CheckResult     `json:"children,omitempty"`
+	DependsOn   []string          `json:"dependsOn,omitempty"`
+	// BlockedBy names the unavailable checkers, at the root of the
+	// dependency chain, that explain why this checker is unavailable.
+	BlockedBy []string `json:"blockedBy,omitempty"`
+	// Maintenance describes the maintenance window or silence the checker
+	// was under, if any.
+	Maintenance string `json:"maintenance,omitempty"`
+	// Attempts is the number of times the check ran, including retries.
+	Attempts int  `json:"attempts"`
+	Flapping bool `json:"flapping"`
+}
+
+// States of a check result, from best to worst.
+const (
+	StateAvailable   = "available"
+	StateDegraded    = "degraded"
+	StateFlapping    = "flapping"
+	StateBlocked     = "blocked"
+	StateUnavailable = "unavailable"
+)
+
+var stateSeverity = map[string]int{StateAvailable: 0, StateDegraded: 1, StateFlapping: 2, StateBlocked: 3, StateUnavailable: 4}
+
+// States returns the states in order, from best to worst.
+func States() []string {
+	return []string{StateAvailable, StateDegraded, StateFlapping, StateBlocked, StateUnavailable}
+}
+
+// State summarizes the result as one of the State constants. Flapping
+// checkers are reported as such whatever their last status.
+func (r CheckResult) State() string {
+	switch {
+	case r.Flapping:
+		return StateFlapping
+	case r.Status:
+		return StateAvailable
+	case r.Degraded:
+		return StateDegraded
+	case len(r.BlockedBy) > 0:
+		return StateBlocked
+	default:
+		return StateUnavailable
+	}
 }
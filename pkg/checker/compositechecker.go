@@ -0,0 +1,124 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// CompositeChecker derives its status from the latest results of other
+// checkers, referenced by name: "and" requires all of them to be available,
+// "or" at least one and "quorum" at least Quorum of them. Degraded checkers
+// count as available but make the composite degraded. Composites can
+// reference other composites to build nested expressions.
+type CompositeChecker struct {
+	CheckerName string
+	// Operator is "and" (default), "or" or "quorum".
+	Operator string
+	Quorum   int
+	Checkers []string
+	results  map[string]CheckResult
+	children []CheckResult
+}
+
+func (c *CompositeChecker) Name() string {
+	return c.CheckerName
+}
+
+func (c *CompositeChecker) References() []string {
+	return c.Checkers
+}
+
+func (c *CompositeChecker) SetResults(results map[string]CheckResult) {
+	c.results = results
+}
+
+func (c *CompositeChecker) Children() []CheckResult {
+	return c.children
+}
+
+func (c *CompositeChecker) Check() (bool, error) {
+	c.children = make([]CheckResult, 0, len(c.Checkers))
+
+	var up int
+	var down, degraded []string
+	for _, name := range c.Checkers {
+		result, ok := c.results[name]
+		if !ok {
+			result = CheckResult{Name: name}
+		}
+		c.children = append(c.children, result)
+
+		switch {
+		case result.Status:
+			up++
+		case result.Degraded:
+			up++
+			degraded = append(degraded, name)
+		default:
+			down = append(down, name)
+		}
+	}
+
+	needed := len(c.Checkers)
+	switch c.Operator {
+	case "or":
+		needed = 1
+	case "quorum":
+		needed = c.Quorum
+	}
+
+	if up < needed {
+		return false, fmt.Errorf("%d of %d checkers available, expected at least %d, down: %s", up, len(c.Checkers), needed, strings.Join(down, ", "))
+	}
+	if len(degraded) > 0 {
+		return false, &DegradedError{Reason: strings.Join(degraded, ", ")}
+	}
+
+	return true, nil
+}
+
+func (c *CompositeChecker) Fix() error {
+	return nil
+}
+
+func (c *CompositeChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("composite", newCompositeChecker)
+}
+
+type compositeConfig struct {
+	Name     string   `yaml:"name"`
+	Operator string   `yaml:"operator"`
+	Quorum   int      `yaml:"quorum"`
+	Checkers []string `yaml:"checkers"`
+}
+
+func newCompositeChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config compositeConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"name": config.Name, "checkers": strings.Join(config.Checkers, ",")})
+	if err != nil {
+		return nil, err
+	}
+	err = oneOf("operator", config.Operator, "", "and", "or", "quorum")
+	if err != nil {
+		return nil, err
+	}
+	if config.Operator == "quorum" && (config.Quorum < 1 || config.Quorum > len(config.Checkers)) {
+		return nil, errors.New("quorum must be between 1 and the number of checkers")
+	}
+
+	return &CompositeChecker{
+		CheckerName: config.Name,
+		Operator:    config.Operator,
+		Quorum:      config.Quorum,
+		Checkers:    config.Checkers,
+	}, nil
+}
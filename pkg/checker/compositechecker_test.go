@@ -0,0 +1,101 @@
+package checker
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompositeChecker_Check(t *testing.T) {
+	results := map[string]CheckResult{
+		"api":     {Name: "api", Status: true},
+		"db":      {Name: "db", Status: true},
+		"redis-1": {Name: "redis-1", Status: true},
+		"redis-2": {Name: "redis-2", Status: true},
+		"redis-3": {Name: "redis-3", Status: false},
+		"cache":   {Name: "cache", Degraded: true},
+	}
+
+	// Test cases
+	testCases := []struct {
+		name             string
+		checker          CompositeChecker
+		expectedSuccess  bool
+		expectedDegraded bool
+	}{
+		{
+			name:            "and with all available",
+			checker:         CompositeChecker{Checkers: []string{"api", "db"}},
+			expectedSuccess: true,
+		},
+		{
+			name:            "and with one down",
+			checker:         CompositeChecker{Operator: "and", Checkers: []string{"api", "redis-3"}},
+			expectedSuccess: false,
+		},
+		{
+			name:            "or with one available",
+			checker:         CompositeChecker{Operator: "or", Checkers: []string{"redis-3", "api"}},
+			expectedSuccess: true,
+		},
+		{
+			name:            "quorum met",
+			checker:         CompositeChecker{Operator: "quorum", Quorum: 2, Checkers: []string{"redis-1", "redis-2", "redis-3"}},
+			expectedSuccess: true,
+		},
+		{
+			name:            "quorum not met",
+			checker:         CompositeChecker{Operator: "quorum", Quorum: 3, Checkers: []string{"redis-1", "redis-2", "redis-3"}},
+			expectedSuccess: false,
+		},
+		{
+			name:             "degraded child",
+			checker:          CompositeChecker{Checkers: []string{"api", "cache"}},
+			expectedSuccess:  false,
+			expectedDegraded: true,
+		},
+		{
+			name:            "missing result counts as down",
+			checker:         CompositeChecker{Checkers: []string{"api", "unknown"}},
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := tc.checker
+			checker.SetResults(results)
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			var degraded *DegradedError
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedDegraded, errors.As(err, &degraded))
+			assert.Len(t, checker.Children(), len(checker.Checkers))
+		})
+	}
+}
+
+func TestCompositeChecker_Children(t *testing.T) {
+	checker := CompositeChecker{CheckerName: "Payments", Checkers: []string{"api", "db"}}
+	checker.SetResults(map[string]CheckResult{"api": {Name: "api", Status: true}})
+
+	checker.Check()
+
+	assert.Equal(t, []CheckResult{{Name: "api", Status: true}, {Name: "db"}}, checker.Children())
+	assert.Equal(t, "Payments", checker.Name())
+	assert.False(t, checker.IsFixable())
+}
+
+func TestNew_Composite(t *testing.T) {
+	_, err := newFromYAML(t, "type: composite\nname: Payments\noperator: quorum\nquorum: 4\ncheckers: [a, b, c]")
+	assert.EqualError(t, err, "composite checker: quorum must be between 1 and the number of checkers")
+
+	c, err := newFromYAML(t, "type: composite\nname: Payments\ncheckers: [a, b]")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, c.(*CompositeChecker).References())
+}
@@ -0,0 +1,66 @@
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCronSchedule_Matches(t *testing.T) {
+	// Sunday 2026-11-01 03:30
+	sunday := time.Date(2026, time.November, 1, 3, 30, 0, 0, time.UTC)
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		expr     string
+		time     time.Time
+		expected bool
+	}{
+		{name: "every minute", expr: "* * * * *", time: sunday, expected: true},
+		{name: "exact minute", expr: "30 3 * * *", time: sunday, expected: true},
+		{name: "other minute", expr: "31 3 * * *", time: sunday, expected: false},
+		{name: "step", expr: "*/15 * * * *", time: sunday, expected: true},
+		{name: "range with step", expr: "0-20/10 * * * *", time: sunday, expected: false},
+		{name: "list", expr: "0,30 1,3 * * *", time: sunday, expected: true},
+		{name: "day name", expr: "30 3 * * sun", time: sunday, expected: true},
+		{name: "sunday as 7", expr: "30 3 * * 7", time: sunday, expected: true},
+		{name: "weekdays", expr: "30 3 * * mon-fri", time: sunday, expected: false},
+		{name: "month name", expr: "30 3 1 nov *", time: sunday, expected: true},
+		{name: "day of month or day of week", expr: "30 3 15 * sun", time: sunday, expected: true},
+		{name: "day of month and any day of week", expr: "30 3 15 * *", time: sunday, expected: false},
+		{name: "day of month step and day of week", expr: "30 3 */2 * mon", time: sunday, expected: false},
+		{name: "day of month step and matching day of week", expr: "30 3 */2 * sun", time: sunday, expected: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s, err := parseCron(tc.expr)
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expected, s.Matches(tc.time))
+		})
+	}
+}
+
+func TestParseCron_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		expr        string
+		expectedErr string
+	}{
+		{expr: "* * * *", expectedErr: `invalid cron expression "* * * *", expected 5 fields`},
+		{expr: "60 * * * *", expectedErr: `invalid minute in cron expression "60 * * * *": invalid value "60", expected 0-59`},
+		{expr: "* * 0 * *", expectedErr: `invalid day of month in cron expression "* * 0 * *": invalid value "0", expected 1-31`},
+		{expr: "*/0 * * * *", expectedErr: `invalid minute in cron expression "*/0 * * * *": invalid step "0"`},
+		{expr: "* 5-2 * * *", expectedErr: `invalid hour in cron expression "* 5-2 * * *": invalid range "5-2"`},
+		{expr: "* * * * funday", expectedErr: `invalid day of week in cron expression "* * * * funday": invalid value "funday", expected 0-7`},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.expr, func(t *testing.T) {
+			_, err := parseCron(tc.expr)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
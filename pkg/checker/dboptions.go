@@ -0,0 +1,134 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// ConnectionOptions are the connection settings shared by the Postgres and
+// MySQL checkers.
+type ConnectionOptions struct {
+	Database string `yaml:"database"`
+	// SSLMode is the driver specific TLS mode, a libpq sslmode for Postgres
+	// or the tls parameter ("true", "false", "skip-verify" or "preferred")
+	// for MySQL.
+	SSLMode string `yaml:"sslMode"`
+	// CACert, ClientCert and ClientKey are paths to PEM encoded files.
+	CACert     string `yaml:"caCert"`
+	ClientCert string `yaml:"clientCert"`
+	ClientKey  string `yaml:"clientKey"`
+	// CACertSecret, ClientCertSecret and ClientKeySecret name credential
+	// provider secrets holding the PEM contents, used instead of the paths.
+	CACertSecret     string        `yaml:"caCertSecret"`
+	ClientCertSecret string        `yaml:"clientCertSecret"`
+	ClientKeySecret  string        `yaml:"clientKeySecret"`
+	ConnectTimeout   time.Duration `yaml:"connectTimeout"`
+	// Params are extra driver parameters added to the connection string.
+	Params map[string]string `yaml:"params"`
+}
+
+func (o ConnectionOptions) usesSecrets() bool {
+	return o.CACertSecret != "" || o.ClientCertSecret != "" || o.ClientKeySecret != ""
+}
+
+func (o ConnectionOptions) usesFiles() bool {
+	return o.CACert != "" || o.ClientCert != "" || o.ClientKey != ""
+}
+
+// certificates returns the PEM contents of the CA bundle, client certificate
+// and client key, read from files or fetched from the credential provider.
+func (o ConnectionOptions) certificates(cp credentialprovider.CredentialProvider) (ca, cert, key string, err error) {
+	if o.usesSecrets() && o.usesFiles() {
+		return "", "", "", errors.New("certificates must come either from files or from secrets, not both")
+	}
+
+	load := func(path, secret string) (string, error) {
+		if secret != "" {
+			value, err := cp.GetSecret(secret)
+			if err != nil {
+				return "", fmt.Errorf("error getting secret %s: %v", secret, err)
+			}
+			return value, nil
+		}
+		if path != "" {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", err
+			}
+			return string(data), nil
+		}
+		return "", nil
+	}
+
+	if ca, err = load(o.CACert, o.CACertSecret); err != nil {
+		return "", "", "", err
+	}
+	if cert, err = load(o.ClientCert, o.ClientCertSecret); err != nil {
+		return "", "", "", err
+	}
+	if key, err = load(o.ClientKey, o.ClientKeySecret); err != nil {
+		return "", "", "", err
+	}
+	return ca, cert, key, nil
+}
+
+// tlsConfig builds a TLS configuration from the PEM contents returned by
+// certificates.
+func (o ConnectionOptions) tlsConfig(ca, cert, key, serverName string) (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName:         serverName,
+		InsecureSkipVerify: o.SSLMode == "skip-verify",
+	}
+
+	if ca != "" {
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM([]byte(ca)) {
+			return nil, errors.New("no valid certificate found in CA bundle")
+		}
+		config.RootCAs = pool
+	}
+
+	if cert != "" || key != "" {
+		keyPair, err := tls.X509KeyPair([]byte(cert), []byte(key))
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %v", err)
+		}
+		config.Certificates = []tls.Certificate{keyPair}
+	}
+
+	return config, nil
+}
+
+// sortedParams returns the extra parameters as "key=value" pairs sorted by
+// key, with values formatted by quote.
+func (o ConnectionOptions) sortedParams(quote func(string) string) []string {
+	keys := make([]string, 0, len(o.Params))
+	for k := range o.Params {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	params := make([]string, 0, len(keys))
+	for _, k := range keys {
+		params = append(params, fmt.Sprintf("%s=%s", k, quote(o.Params[k])))
+	}
+	return params
+}
+
+// quotePgValue quotes a libpq keyword/value connection string value when it
+// is empty or contains spaces, quotes or backslashes.
+func quotePgValue(value string) string {
+	if value != "" && !strings.ContainsAny(value, " '\\\t\n") {
+		return value
+	}
+	value = strings.ReplaceAll(value, `\`, `\\`)
+	value = strings.ReplaceAll(value, `'`, `\'`)
+	return "'" + value + "'"
+}
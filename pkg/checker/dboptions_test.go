@@ -0,0 +1,223 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostgresChecker_ConnectionString(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		options         ConnectionOptions
+		expectedConnStr string
+		expectErr       bool
+	}{
+		{
+			name:            "defaults",
+			options:         ConnectionOptions{},
+			expectedConnStr: "host=127.0.0.1 port=5432 dbname=postgres user=mockuser password=mockpassword sslmode=disable connect_timeout=10",
+		},
+		{
+			name: "tls with certificate files and extra params",
+			options: ConnectionOptions{
+				Database:       "orders",
+				SSLMode:        "verify-full",
+				CACert:         "/etc/ssl/ca.pem",
+				ClientCert:     "/etc/ssl/client.pem",
+				ClientKey:      "/etc/ssl/client.key",
+				ConnectTimeout: 5 * time.Second,
+				Params:         map[string]string{"application_name": "availability checker", "target_session_attrs": "read-write"},
+			},
+			expectedConnStr: "host=127.0.0.1 port=5432 dbname=orders user=mockuser password=mockpassword sslmode=verify-full connect_timeout=5 sslrootcert=/etc/ssl/ca.pem sslcert=/etc/ssl/client.pem sslkey=/etc/ssl/client.key application_name='availability checker' target_session_attrs=read-write",
+		},
+		{
+			name:            "sub-second timeout rounded up",
+			options:         ConnectionOptions{ConnectTimeout: 500 * time.Millisecond},
+			expectedConnStr: "host=127.0.0.1 port=5432 dbname=postgres user=mockuser password=mockpassword sslmode=disable connect_timeout=1",
+		},
+		{
+			name:            "fractional timeout rounded up",
+			options:         ConnectionOptions{ConnectTimeout: 2500 * time.Millisecond},
+			expectedConnStr: "host=127.0.0.1 port=5432 dbname=postgres user=mockuser password=mockpassword sslmode=disable connect_timeout=3",
+		},
+		{
+			name: "certificates from secrets",
+			options: ConnectionOptions{
+				SSLMode:      "verify-ca",
+				CACertSecret: "pg-ca",
+			},
+			expectedConnStr: "host=127.0.0.1 port=5432 dbname=postgres user=mockuser password=mockpassword sslmode=verify-ca connect_timeout=10 sslinline=true sslrootcert=mocksecret-pg-ca",
+		},
+		{
+			name: "certificates from files and secrets",
+			options: ConnectionOptions{
+				CACert:          "/etc/ssl/ca.pem",
+				ClientKeySecret: "pg-key",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := PostgresChecker{
+				Server:             "127.0.0.1",
+				Port:               "5432",
+				Options:            tc.options,
+				CredentialProvider: &credentialprovider.MockCredentialProvider{},
+			}
+
+			connStr, err := checker.connectionString("mockuser", "mockpassword")
+
+			assert.Equal(t, tc.expectErr, err != nil)
+			assert.Equal(t, tc.expectedConnStr, connStr)
+		})
+	}
+}
+
+func TestMySQLChecker_ConnectionString(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		options         ConnectionOptions
+		expectedConnStr string
+		expectErr       bool
+	}{
+		{
+			name:            "defaults",
+			options:         ConnectionOptions{},
+			expectedConnStr: "mockuser:mockpassword@tcp(127.0.0.1:3306)/",
+		},
+		{
+			name: "database, tls mode, timeout and params",
+			options: ConnectionOptions{
+				Database:       "orders",
+				SSLMode:        "skip-verify",
+				ConnectTimeout: 5 * time.Second,
+				Params:         map[string]string{"charset": "utf8mb4"},
+			},
+			expectedConnStr: "mockuser:mockpassword@tcp(127.0.0.1:3306)/orders?timeout=5s&tls=skip-verify&charset=utf8mb4",
+		},
+		{
+			name: "invalid CA bundle",
+			options: ConnectionOptions{
+				CACertSecret: "mysql-ca",
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := MySQLChecker{
+				Server:             "127.0.0.1",
+				Port:               "3306",
+				Options:            tc.options,
+				CredentialProvider: &credentialprovider.MockCredentialProvider{},
+			}
+
+			connStr, err := checker.connectionString("mockuser", "mockpassword")
+
+			assert.Equal(t, tc.expectErr, err != nil)
+			assert.Equal(t, tc.expectedConnStr, connStr)
+		})
+	}
+}
+
+// writeTestCA writes a self-signed CA certificate to a file in dir.
+func writeTestCA(t *testing.T, dir, name string) string {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.Nil(t, err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	assert.Nil(t, err)
+
+	path := filepath.Join(dir, name+".pem")
+	err = os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600)
+	assert.Nil(t, err)
+	return path
+}
+
+func TestMySQLChecker_ConnectionStringTLSConfigPerChecker(t *testing.T) {
+	dir := t.TempDir()
+	first := MySQLChecker{
+		Server:             "127.0.0.1",
+		Port:               "3306",
+		Options:            ConnectionOptions{CACert: writeTestCA(t, dir, "first")},
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+	second := MySQLChecker{
+		Server:             "127.0.0.1",
+		Port:               "3306",
+		Options:            ConnectionOptions{CACert: writeTestCA(t, dir, "second")},
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	firstConnStr, err := first.connectionString("mockuser", "mockpassword")
+	assert.Nil(t, err)
+	secondConnStr, err := second.connectionString("mockuser", "mockpassword")
+	assert.Nil(t, err)
+
+	// Checkers on the same server register their configuration under
+	// different names, and keep their name across checks
+	assert.NotEqual(t, first.tlsConfigName, second.tlsConfigName)
+	assert.Contains(t, firstConnStr, "tls="+first.tlsConfigName)
+	assert.Contains(t, secondConnStr, "tls="+second.tlsConfigName)
+	againConnStr, err := first.connectionString("mockuser", "mockpassword")
+	assert.Nil(t, err)
+	assert.Equal(t, firstConnStr, againConnStr)
+
+	assert.Nil(t, first.Close())
+	assert.Nil(t, second.Close())
+}
+
+func TestConnectionOptions_Certificates(t *testing.T) {
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	err := os.WriteFile(caPath, []byte("ca contents"), 0600)
+	assert.Nil(t, err)
+
+	options := ConnectionOptions{CACert: caPath}
+	ca, cert, key, err := options.certificates(&credentialprovider.MockCredentialProvider{})
+	assert.Nil(t, err)
+	assert.Equal(t, "ca contents", ca)
+	assert.Equal(t, "", cert)
+	assert.Equal(t, "", key)
+
+	options = ConnectionOptions{ClientCertSecret: "client-cert", ClientKeySecret: "client-key"}
+	ca, cert, key, err = options.certificates(&credentialprovider.MockCredentialProvider{})
+	assert.Nil(t, err)
+	assert.Equal(t, "", ca)
+	assert.Equal(t, "mocksecret-client-cert", cert)
+	assert.Equal(t, "mocksecret-client-key", key)
+
+	options = ConnectionOptions{CACert: filepath.Join(dir, "missing.pem")}
+	_, _, _, err = options.certificates(&credentialprovider.MockCredentialProvider{})
+	assert.NotNil(t, err)
+}
+
+func TestQuotePgValue(t *testing.T) {
+	assert.Equal(t, "plain", quotePgValue("plain"))
+	assert.Equal(t, "''", quotePgValue(""))
+	assert.Equal(t, `'it\'s a pass\\word'`, quotePgValue(`it's a pass\word`))
+}
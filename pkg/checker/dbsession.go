@@ -0,0 +1,115 @@
+package checker
+
+import (
+	"availability-checker/pkg/database"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+)
+
+const defaultMaxConsecutiveErrors = 3
+
+// poolConfig holds the connection pool settings of the database checkers.
+type poolConfig struct {
+	ReuseConnection      bool          `yaml:"reuseConnection"`
+	MaxConsecutiveErrors int           `yaml:"maxConsecutiveErrors"`
+	MaxOpenConns         int           `yaml:"maxOpenConns"`
+	MaxIdleConns         int           `yaml:"maxIdleConns"`
+	ConnMaxLifetime      time.Duration `yaml:"connMaxLifetime"`
+}
+
+func (p poolConfig) connection() *database.SQLDBConnection {
+	conn := &database.SQLDBConnection{
+		MaxOpenConns:    p.MaxOpenConns,
+		MaxIdleConns:    p.MaxIdleConns,
+		ConnMaxLifetime: p.ConnMaxLifetime,
+	}
+	if p.ReuseConnection && conn.MaxOpenConns == 0 {
+		// Keep long-lived pools small, a check only needs one connection
+		conn.MaxOpenConns = 2
+	}
+	return conn
+}
+
+// dbSession keeps the connection pool of a database checker open between
+// checks when ReuseConnection is enabled. The pool is re-created when the
+// data source changes, e.g. on credential or certificate rotation, or after
+// MaxConsecutiveErrors failed checks in a row.
+type dbSession struct {
+	dataSource        string
+	open              bool
+	consecutiveErrors int
+}
+
+// connect opens conn for the data source unless it is reused and already
+// open for it. The returned release function must be called with the
+// outcome of the check.
+func (s *dbSession) connect(conn database.DBConnection, reuse bool, maxConsecutiveErrors int, driverName, dataSource string) (func(error), error) {
+	if !reuse {
+		err := conn.Open(driverName, dataSource)
+		if err != nil {
+			return nil, err
+		}
+		return func(error) { conn.Close() }, nil
+	}
+
+	if s.open && s.dataSource != dataSource {
+		log.Printf("Data source changed, re-creating %s connection pool\n", driverName)
+		s.close(conn)
+	}
+
+	if !s.open {
+		err := conn.Open(driverName, dataSource)
+		if err != nil {
+			return nil, err
+		}
+		s.open = true
+		s.dataSource = dataSource
+		s.consecutiveErrors = 0
+	}
+
+	if maxConsecutiveErrors == 0 {
+		maxConsecutiveErrors = defaultMaxConsecutiveErrors
+	}
+
+	return func(err error) {
+		if err == nil {
+			s.consecutiveErrors = 0
+			return
+		}
+		s.consecutiveErrors++
+		if s.consecutiveErrors >= maxConsecutiveErrors {
+			log.Printf("%d consecutive errors, re-creating %s connection pool\n", s.consecutiveErrors, driverName)
+			s.close(conn)
+		}
+	}, nil
+}
+
+func (s *dbSession) close(conn database.DBConnection) {
+	conn.Close()
+	s.open = false
+	s.dataSource = ""
+	s.consecutiveErrors = 0
+}
+
+// shutdown closes the pool if it is open.
+func (s *dbSession) shutdown(conn database.DBConnection) {
+	if s.open {
+		s.close(conn)
+	}
+}
+
+// poolDetails reports the health of a reused connection pool.
+func (s *dbSession) poolDetails(conn database.DBConnection, details map[string]string) {
+	if !s.open {
+		return
+	}
+	stats := conn.Stats()
+	details["pool_open_connections"] = strconv.Itoa(stats.OpenConnections)
+	details["pool_in_use"] = strconv.Itoa(stats.InUse)
+	details["pool_idle"] = strconv.Itoa(stats.Idle)
+	details["pool_wait_count"] = strconv.FormatInt(stats.WaitCount, 10)
+	details["pool_wait_duration"] = stats.WaitDuration.String()
+	details["pool_consecutive_errors"] = fmt.Sprint(s.consecutiveErrors)
+}
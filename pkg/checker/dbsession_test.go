@@ -0,0 +1,162 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDBSession_ConnectWithoutReuse(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", "dsn").Return(nil).Twice()
+	mockConn.On("Close").Return(nil).Twice()
+
+	var session dbSession
+	for i := 0; i < 2; i++ {
+		release, err := session.connect(mockConn, false, 0, "postgres", "dsn")
+		assert.Nil(t, err)
+		release(nil)
+	}
+
+	mockConn.AssertExpectations(t)
+}
+
+func TestDBSession_ConnectWithReuse(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", "dsn").Return(nil).Once()
+
+	var session dbSession
+	for i := 0; i < 3; i++ {
+		release, err := session.connect(mockConn, true, 0, "postgres", "dsn")
+		assert.Nil(t, err)
+		release(nil)
+	}
+
+	mockConn.AssertExpectations(t)
+	mockConn.AssertNotCalled(t, "Close")
+}
+
+func TestDBSession_ReconnectOnDataSourceChange(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", "old-dsn").Return(nil).Once()
+	mockConn.On("Close").Return(nil).Once()
+	mockConn.On("Open", "postgres", "new-dsn").Return(nil).Once()
+
+	var session dbSession
+	release, err := session.connect(mockConn, true, 0, "postgres", "old-dsn")
+	assert.Nil(t, err)
+	release(nil)
+
+	release, err = session.connect(mockConn, true, 0, "postgres", "new-dsn")
+	assert.Nil(t, err)
+	release(nil)
+
+	mockConn.AssertExpectations(t)
+}
+
+func TestDBSession_ReconnectAfterConsecutiveErrors(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", "dsn").Return(nil).Twice()
+	mockConn.On("Close").Return(nil).Once()
+
+	var session dbSession
+	for i := 0; i < 3; i++ {
+		release, err := session.connect(mockConn, true, 2, "postgres", "dsn")
+		assert.Nil(t, err)
+		release(errors.New("ping error"))
+	}
+
+	mockConn.AssertExpectations(t)
+}
+
+func TestPostgresChecker_CheckReusesConnection(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", mock.Anything).Return(nil).Once()
+	mockConn.On("Ping").Return(nil).Twice()
+	mockConn.On("Stats").Return(sql.DBStats{OpenConnections: 1, Idle: 1})
+
+	checker := PostgresChecker{
+		Server:             "127.0.0.1",
+		Port:               "5432",
+		ReuseConnection:    true,
+		DBConnection:       mockConn,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	for i := 0; i < 2; i++ {
+		success, err := checker.Check()
+		assert.True(t, success)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, "1", checker.Details()["pool_open_connections"])
+	assert.Equal(t, "1", checker.Details()["pool_idle"])
+	mockConn.AssertExpectations(t)
+	mockConn.AssertNotCalled(t, "Close")
+}
+
+func TestPostgresChecker_CloseReusedConnection(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", mock.Anything).Return(nil).Once()
+	mockConn.On("Ping").Return(nil).Once()
+	mockConn.On("Stats").Return(sql.DBStats{OpenConnections: 1})
+	mockConn.On("Close").Return(nil).Once()
+
+	checker := PostgresChecker{
+		Server:             "127.0.0.1",
+		Port:               "5432",
+		ReuseConnection:    true,
+		DBConnection:       mockConn,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	_, err := checker.Check()
+	assert.Nil(t, err)
+
+	// Closing twice only closes the pool once
+	assert.Nil(t, checker.Close())
+	assert.Nil(t, checker.Close())
+	mockConn.AssertExpectations(t)
+}
+
+func TestMySQLChecker_CheckRecreatesPoolOnCertificateRotation(t *testing.T) {
+	dir := t.TempDir()
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "mysql", mock.Anything).Return(nil).Twice()
+	mockConn.On("Ping").Return(nil).Times(3)
+	mockConn.On("Stats").Return(sql.DBStats{OpenConnections: 1})
+	mockConn.On("Close").Return(nil).Twice()
+
+	checker := MySQLChecker{
+		Server:             "127.0.0.1",
+		Port:               "3306",
+		Options:            ConnectionOptions{CACert: writeTestCA(t, dir, "ca")},
+		ReuseConnection:    true,
+		DBConnection:       mockConn,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	// The pool is reused while the certificates don't change
+	for i := 0; i < 2; i++ {
+		success, err := checker.Check()
+		assert.True(t, success)
+		assert.Nil(t, err)
+	}
+	mockConn.AssertNumberOfCalls(t, "Open", 1)
+	name := checker.tlsConfigName
+
+	// Rotating the CA changes the data source, which re-creates the pool
+	writeTestCA(t, dir, "ca")
+	success, err := checker.Check()
+	assert.True(t, success)
+	assert.Nil(t, err)
+	assert.NotEqual(t, name, checker.tlsConfigName)
+	mockConn.AssertNumberOfCalls(t, "Open", 2)
+
+	assert.Nil(t, checker.Close())
+	mockConn.AssertExpectations(t)
+}
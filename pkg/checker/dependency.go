@@ -0,0 +1,63 @@
+package checker
+
+// BlockedBy returns the root causes among the unavailable dependencies of a
+// checker: the dependencies that are down themselves, or the checkers those
+// are blocked by. It returns nil when all dependencies are available.
+func BlockedBy(dependsOn []string, results map[string]CheckResult) []string {
+	var causes []string
+	seen := make(map[string]bool)
+	for _, name := range dependsOn {
+		result := results[name]
+		if result.Status || result.Degraded {
+			continue
+		}
+		roots := result.BlockedBy
+		if len(roots) == 0 {
+			roots = []string{name}
+		}
+		for _, root := range roots {
+			if !seen[root] {
+				seen[root] = true
+				causes = append(causes, root)
+			}
+		}
+	}
+	return causes
+}
+
+// DependencyNode is a checker result along with the results of the checkers
+// that depend on it.
+type DependencyNode struct {
+	Result     CheckResult
+	Dependents []DependencyNode
+}
+
+// DependencyTree arranges results by dependency, starting from the checkers
+// that others depend on but that don't depend on anything themselves.
+// Checkers depending on several others appear under each of them. Results
+// not involved in any dependency are left out.
+func DependencyTree(results []CheckResult) []DependencyNode {
+	dependents := make(map[string][]CheckResult)
+	for _, result := range results {
+		for _, name := range result.DependsOn {
+			dependents[name] = append(dependents[name], result)
+		}
+	}
+
+	var node func(result CheckResult) DependencyNode
+	node = func(result CheckResult) DependencyNode {
+		n := DependencyNode{Result: result}
+		for _, dependent := range dependents[result.Name] {
+			n.Dependents = append(n.Dependents, node(dependent))
+		}
+		return n
+	}
+
+	var roots []DependencyNode
+	for _, result := range results {
+		if len(result.DependsOn) == 0 && len(dependents[result.Name]) > 0 {
+			roots = append(roots, node(result))
+		}
+	}
+	return roots
+}
@@ -0,0 +1,67 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockedBy(t *testing.T) {
+	results := map[string]CheckResult{
+		"db":    {Name: "db"},
+		"cache": {Name: "cache", Degraded: true},
+		"dns":   {Name: "dns", Status: true},
+		"api":   {Name: "api", BlockedBy: []string{"db"}},
+		"queue": {Name: "queue"},
+	}
+
+	// Test cases
+	testCases := []struct {
+		name      string
+		dependsOn []string
+		expected  []string
+	}{
+		{
+			name:      "no dependencies",
+			dependsOn: nil,
+			expected:  nil,
+		},
+		{
+			name:      "available and degraded dependencies",
+			dependsOn: []string{"dns", "cache"},
+			expected:  nil,
+		},
+		{
+			name:      "down dependency",
+			dependsOn: []string{"dns", "db"},
+			expected:  []string{"db"},
+		},
+		{
+			name:      "blocked dependency reports its root cause",
+			dependsOn: []string{"api", "db", "queue"},
+			expected:  []string{"db", "queue"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, BlockedBy(tc.dependsOn, results))
+		})
+	}
+}
+
+func TestDependencyTree(t *testing.T) {
+	db := CheckResult{Name: "db"}
+	api := CheckResult{Name: "api", DependsOn: []string{"db"}}
+	web := CheckResult{Name: "web", DependsOn: []string{"api", "db"}}
+	dns := CheckResult{Name: "dns", Status: true}
+
+	tree := DependencyTree([]CheckResult{api, db, dns, web})
+
+	assert.Equal(t, []DependencyNode{
+		{Result: db, Dependents: []DependencyNode{
+			{Result: api, Dependents: []DependencyNode{{Result: web}}},
+			{Result: web},
+		}},
+	}, tree)
+}
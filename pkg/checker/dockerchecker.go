@@ -0,0 +1,207 @@
+package checker
+
+import (
+	"availability-checker/pkg/docker"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+)
+
+const defaultDockerTimeout = 10 * time.Second
+
+// DockerChecker inspects the containers matching Container (a name) or
+// Labels and requires them to be running and, when they define a
+// HEALTHCHECK, healthy. Fix restarts the containers that failed the last
+// check.
+type DockerChecker struct {
+	Container    string
+	Labels       map[string]string
+	Timeout      time.Duration
+	DockerClient docker.ContainerClient
+	// mu guards the results of the last check, as Fix is called from the
+	// web server while checks run
+	mu        sync.Mutex
+	details   map[string]string
+	unhealthy []string
+}
+
+func (c *DockerChecker) Name() string {
+	if c.Container != "" {
+		return fmt.Sprintf("Docker: %s", c.Container)
+	}
+	labels := make([]string, 0, len(c.Labels))
+	for key, value := range c.Labels {
+		labels = append(labels, fmt.Sprintf("%s=%s", key, value))
+	}
+	sort.Strings(labels)
+	return fmt.Sprintf("Docker: %s", strings.Join(labels, ","))
+}
+
+func (c *DockerChecker) context() (context.Context, context.CancelFunc) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultDockerTimeout
+	}
+	return context.WithTimeout(context.Background(), timeout)
+}
+
+func (c *DockerChecker) Check() (bool, error) {
+	details := make(map[string]string)
+	var unhealthy []string
+	defer func() {
+		c.mu.Lock()
+		c.details, c.unhealthy = details, unhealthy
+		c.mu.Unlock()
+	}()
+
+	err := c.DockerClient.NewClient()
+	if err != nil {
+		return false, fmt.Errorf("error creating docker client: %v", err)
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	containers, err := c.containers(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var failures []string
+	for _, listed := range containers {
+		inspected, err := c.DockerClient.ContainerInspect(ctx, listed.ID)
+		if err != nil {
+			return false, fmt.Errorf("error inspecting container %s: %v", listed.ID, err)
+		}
+		if inspected.ContainerJSONBase == nil || inspected.State == nil {
+			return false, fmt.Errorf("no state for container %s", listed.ID)
+		}
+
+		name := strings.TrimPrefix(inspected.Name, "/")
+		state := inspected.State
+		details[name+".state"] = state.Status
+		details[name+".restarts"] = strconv.Itoa(inspected.RestartCount)
+		if state.Health != nil {
+			details[name+".health"] = state.Health.Status
+		}
+
+		if !state.Running || state.Restarting {
+			failures = append(failures, fmt.Sprintf("container %s is %s", name, state.Status))
+			unhealthy = append(unhealthy, listed.ID)
+		} else if state.Health != nil && state.Health.Status != types.Healthy {
+			failures = append(failures, fmt.Sprintf("container %s is %s", name, state.Health.Status))
+			unhealthy = append(unhealthy, listed.ID)
+		}
+	}
+
+	if len(failures) > 0 {
+		return false, errors.New(strings.Join(failures, "; "))
+	}
+
+	return true, nil
+}
+
+// containers lists the containers matched by name or labels, including
+// stopped ones.
+func (c *DockerChecker) containers(ctx context.Context) ([]types.Container, error) {
+	args := filters.NewArgs()
+	if c.Container != "" {
+		args.Add("name", fmt.Sprintf("^/%s$", c.Container))
+	}
+	for key, value := range c.Labels {
+		args.Add("label", fmt.Sprintf("%s=%s", key, value))
+	}
+	if args.Len() == 0 {
+		return nil, errors.New("empty container name and labels")
+	}
+
+	containers, err := c.DockerClient.ContainerList(ctx, types.ContainerListOptions{All: true, Filters: args})
+	if err != nil {
+		return nil, fmt.Errorf("error listing containers: %v", err)
+	}
+	if len(containers) == 0 {
+		return nil, errors.New("no matching container found")
+	}
+
+	return containers, nil
+}
+
+func (c *DockerChecker) Details() map[string]string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.details
+}
+
+// Fix only restarts the containers the last check found unhealthy, it never
+// touches containers that weren't checked or passed.
+func (c *DockerChecker) Fix() error {
+	c.mu.Lock()
+	ids := append([]string(nil), c.unhealthy...)
+	c.mu.Unlock()
+	if len(ids) == 0 {
+		return errors.New("no unhealthy containers")
+	}
+
+	err := c.DockerClient.NewClient()
+	if err != nil {
+		return fmt.Errorf("error creating docker client: %v", err)
+	}
+
+	// Restarts wait for the container stop timeout, so they are not bound
+	// by the checker timeout
+	for _, id := range ids {
+		err := c.DockerClient.ContainerRestart(context.Background(), id, container.StopOptions{})
+		if err != nil {
+			return fmt.Errorf("error restarting container %s: %v", id, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *DockerChecker) IsFixable() bool {
+	return true
+}
+
+// Close releases the Docker client.
+func (c *DockerChecker) Close() error {
+	return c.DockerClient.Close()
+}
+
+func init() {
+	Register("docker", newDockerChecker)
+}
+
+type dockerConfig struct {
+	Host      string            `yaml:"host"`
+	Container string            `yaml:"container"`
+	Labels    map[string]string `yaml:"labels"`
+	Timeout   time.Duration     `yaml:"timeout"`
+}
+
+func newDockerChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config dockerConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	if config.Container == "" && len(config.Labels) == 0 {
+		return nil, errors.New("missing required field(s): container or labels")
+	}
+
+	return &DockerChecker{
+		Container:    config.Container,
+		Labels:       config.Labels,
+		Timeout:      config.Timeout,
+		DockerClient: &docker.EngineClient{Host: config.Host},
+	}, nil
+}
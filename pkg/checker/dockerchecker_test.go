@@ -0,0 +1,190 @@
+package checker
+
+import (
+	"availability-checker/pkg/docker"
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	dct "github.com/docker/docker/api/types/container"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func (m *mockStruct) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	args := m.Called(ctx, containerID)
+	return args.Get(0).(types.ContainerJSON), args.Error(1)
+}
+
+func (m *mockStruct) ContainerRestart(ctx context.Context, containerID string, options dct.StopOptions) error {
+	args := m.Called(ctx, containerID, options)
+	return args.Error(0)
+}
+
+func containerJSON(name, status string, health string) types.ContainerJSON {
+	state := &types.ContainerState{Status: status, Running: status == "running"}
+	if health != "" {
+		state.Health = &types.Health{Status: health}
+	}
+	return types.ContainerJSON{ContainerJSONBase: &types.ContainerJSONBase{Name: "/" + name, State: state}}
+}
+
+func TestDockerChecker_Check(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		containers      []types.Container
+		listErr         error
+		inspected       map[string]types.ContainerJSON
+		expectedSuccess bool
+		expectedDetails map[string]string
+	}{
+		{
+			name:            "running without healthcheck",
+			containers:      []types.Container{{ID: "abc"}},
+			inspected:       map[string]types.ContainerJSON{"abc": containerJSON("web", "running", "")},
+			expectedSuccess: true,
+			expectedDetails: map[string]string{"web.state": "running", "web.restarts": "0"},
+		},
+		{
+			name:            "running and healthy",
+			containers:      []types.Container{{ID: "abc"}},
+			inspected:       map[string]types.ContainerJSON{"abc": containerJSON("web", "running", types.Healthy)},
+			expectedSuccess: true,
+			expectedDetails: map[string]string{"web.state": "running", "web.restarts": "0", "web.health": "healthy"},
+		},
+		{
+			name:            "running but unhealthy",
+			containers:      []types.Container{{ID: "abc"}},
+			inspected:       map[string]types.ContainerJSON{"abc": containerJSON("web", "running", types.Unhealthy)},
+			expectedSuccess: false,
+			expectedDetails: map[string]string{"web.state": "running", "web.restarts": "0", "web.health": "unhealthy"},
+		},
+		{
+			name:            "exited",
+			containers:      []types.Container{{ID: "abc"}},
+			inspected:       map[string]types.ContainerJSON{"abc": containerJSON("web", "exited", "")},
+			expectedSuccess: false,
+			expectedDetails: map[string]string{"web.state": "exited", "web.restarts": "0"},
+		},
+		{
+			name:            "no matching container",
+			containers:      []types.Container{},
+			expectedSuccess: false,
+			expectedDetails: map[string]string{},
+		},
+		{
+			name:            "list error",
+			containers:      []types.Container{},
+			listErr:         errors.New("cannot connect to the docker daemon"),
+			expectedSuccess: false,
+			expectedDetails: map[string]string{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the mock docker client
+			mockClient := new(mockStruct)
+			mockClient.On("NewClient").Return(nil)
+			mockClient.On("ContainerList", mock.Anything, mock.MatchedBy(func(opts types.ContainerListOptions) bool {
+				return opts.All && opts.Filters.ExactMatch("name", "^/web$")
+			})).Return(tc.containers, tc.listErr)
+			for id, inspected := range tc.inspected {
+				mockClient.On("ContainerInspect", mock.Anything, id).Return(inspected, nil)
+			}
+
+			checker := DockerChecker{Container: "web", DockerClient: mockClient}
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedDetails, checker.Details())
+			mockClient.AssertExpectations(t)
+		})
+	}
+}
+
+func TestDockerChecker_FixRestartsUnhealthyContainers(t *testing.T) {
+	mockClient := new(mockStruct)
+	mockClient.On("NewClient").Return(nil)
+	mockClient.On("ContainerList", mock.Anything, mock.Anything).Return([]types.Container{{ID: "abc"}, {ID: "def"}}, nil)
+	mockClient.On("ContainerInspect", mock.Anything, "abc").Return(containerJSON("web-1", "running", types.Healthy), nil)
+	mockClient.On("ContainerInspect", mock.Anything, "def").Return(containerJSON("web-2", "running", types.Unhealthy), nil)
+	mockClient.On("ContainerRestart", mock.Anything, "def", dct.StopOptions{}).Return(nil).Once()
+
+	checker := DockerChecker{Labels: map[string]string{"app": "web"}, DockerClient: mockClient}
+
+	success, _ := checker.Check()
+	assert.False(t, success)
+
+	err := checker.Fix()
+	assert.Nil(t, err)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "ContainerRestart", mock.Anything, "abc", mock.Anything)
+}
+
+func TestDockerChecker_FixWithoutUnhealthyContainers(t *testing.T) {
+	mockClient := new(mockStruct)
+	mockClient.On("NewClient").Return(nil)
+	mockClient.On("ContainerList", mock.Anything, mock.Anything).Return([]types.Container{{ID: "abc"}}, nil)
+	mockClient.On("ContainerInspect", mock.Anything, "abc").Return(containerJSON("web", "running", types.Healthy), nil)
+
+	checker := DockerChecker{Container: "web", DockerClient: mockClient}
+
+	// Before the first check
+	err := checker.Fix()
+	assert.EqualError(t, err, "no unhealthy containers")
+
+	success, _ := checker.Check()
+	assert.True(t, success)
+
+	err = checker.Fix()
+	assert.EqualError(t, err, "no unhealthy containers")
+	mockClient.AssertNotCalled(t, "ContainerRestart", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDockerChecker_Close(t *testing.T) {
+	mockClient := new(mockStruct)
+	mockClient.On("Close").Return(nil).Once()
+	checker := DockerChecker{Container: "web", DockerClient: mockClient}
+
+	err := checker.Close()
+
+	assert.Nil(t, err)
+	mockClient.AssertExpectations(t)
+}
+
+func TestEngineClient_ConcurrentNewClient(t *testing.T) {
+	// Creating the client doesn't connect to the daemon
+	engine := &docker.EngineClient{Host: "unix:///nonexistent/docker.sock"}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.Nil(t, engine.NewClient())
+		}()
+	}
+	wg.Wait()
+
+	assert.Nil(t, engine.Close())
+	_, err := engine.ContainerList(context.Background(), types.ContainerListOptions{})
+	assert.EqualError(t, err, "docker client not created")
+}
+
+func TestDockerChecker_Name(t *testing.T) {
+	assert.Equal(t, "Docker: web", (&DockerChecker{Container: "web"}).Name())
+	assert.Equal(t, "Docker: app=web,tier=frontend", (&DockerChecker{Labels: map[string]string{"tier": "frontend", "app": "web"}}).Name())
+}
+
+func TestDockerChecker_IsFixable(t *testing.T) {
+	checker := DockerChecker{}
+	assert.True(t, checker.IsFixable())
+}
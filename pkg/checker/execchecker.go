@@ -0,0 +1,176 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/process"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"time"
+)
+
+const defaultExecTimeout = 30 * time.Second
+
+// Nagios plugin exit codes.
+const (
+	execExitOK       = 0
+	execExitDegraded = 1
+	execExitDown     = 2
+)
+
+// ExecChecker runs a command and interprets its exit code the way Nagios
+// does: 0 is available, 1 is degraded and 2 (or anything else) is
+// unavailable. Its stdout is reported as the check message, so existing
+// Nagios plugins can be used as is.
+type ExecChecker struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+	// SecretEnv maps environment variables to secrets read from the
+	// credential provider.
+	SecretEnv          map[string]string
+	WorkingDir         string
+	Timeout            time.Duration
+	CredentialProvider credentialprovider.CredentialProvider
+	message            string
+}
+
+func (c *ExecChecker) Name() string {
+	return "Exec: " + strings.Join(append([]string{c.Command}, c.Args...), " ")
+}
+
+func (c *ExecChecker) Check() (bool, error) {
+	c.message = ""
+
+	env, err := c.environment()
+	if err != nil {
+		return false, err
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultExecTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdout, stderr bytes.Buffer
+	cmd := process.Command(ctx, c.Command, c.Args...)
+	cmd.Env = env
+	cmd.Dir = c.WorkingDir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err = process.Run(cmd)
+	c.message = strings.TrimSpace(stdout.String())
+	if ctx.Err() == context.DeadlineExceeded {
+		return false, fmt.Errorf("command timed out after %s", timeout)
+	}
+
+	exitCode := execExitOK
+	if err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			return false, fmt.Errorf("error running command: %v", err)
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	output := c.message
+	if output == "" {
+		output = strings.TrimSpace(stderr.String())
+	}
+
+	switch exitCode {
+	case execExitOK:
+		return true, nil
+	case execExitDegraded:
+		return false, &DegradedError{Reason: output}
+	case execExitDown:
+		return false, fmt.Errorf("down: %s", output)
+	default:
+		return false, fmt.Errorf("unknown state, exit code %d: %s", exitCode, output)
+	}
+}
+
+// environment returns the process environment extended with Env and the
+// secrets named in SecretEnv.
+func (c *ExecChecker) environment() ([]string, error) {
+	env := os.Environ()
+
+	keys := make([]string, 0, len(c.Env))
+	for key := range c.Env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		env = append(env, fmt.Sprintf("%s=%s", key, c.Env[key]))
+	}
+
+	keys = keys[:0]
+	for key := range c.SecretEnv {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		secret, err := c.CredentialProvider.GetSecret(c.SecretEnv[key])
+		if err != nil {
+			return nil, fmt.Errorf("error getting secret %s: %v", c.SecretEnv[key], err)
+		}
+		env = append(env, fmt.Sprintf("%s=%s", key, secret))
+	}
+
+	return env, nil
+}
+
+func (c *ExecChecker) Message() string {
+	return c.message
+}
+
+func (c *ExecChecker) Fix() error {
+	return nil
+}
+
+func (c *ExecChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("exec", newExecChecker)
+}
+
+type execConfig struct {
+	Command    string            `yaml:"command"`
+	Args       []string          `yaml:"args"`
+	Env        map[string]string `yaml:"env"`
+	SecretEnv  map[string]string `yaml:"secretEnv"`
+	WorkingDir string            `yaml:"workingDir"`
+	Timeout    time.Duration     `yaml:"timeout"`
+}
+
+func newExecChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config execConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"command": config.Command})
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExecChecker{
+		Command:            config.Command,
+		Args:               config.Args,
+		Env:                config.Env,
+		SecretEnv:          config.SecretEnv,
+		WorkingDir:         config.WorkingDir,
+		Timeout:            config.Timeout,
+		CredentialProvider: deps.CredentialProvider,
+	}, nil
+}
@@ -0,0 +1,134 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecChecker_Check(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name             string
+		checker          ExecChecker
+		expectedSuccess  bool
+		expectedDegraded bool
+		expectedMessage  string
+	}{
+		{
+			name:            "exit code 0 is available",
+			checker:         ExecChecker{Command: "sh", Args: []string{"-c", "echo 'OK - all good'"}},
+			expectedSuccess: true,
+			expectedMessage: "OK - all good",
+		},
+		{
+			name:             "exit code 1 is degraded",
+			checker:          ExecChecker{Command: "sh", Args: []string{"-c", "echo 'WARNING - disk 85%'; exit 1"}},
+			expectedSuccess:  false,
+			expectedDegraded: true,
+			expectedMessage:  "WARNING - disk 85%",
+		},
+		{
+			name:            "exit code 2 is down",
+			checker:         ExecChecker{Command: "sh", Args: []string{"-c", "echo 'CRITICAL - disk 99%'; exit 2"}},
+			expectedSuccess: false,
+			expectedMessage: "CRITICAL - disk 99%",
+		},
+		{
+			name:            "unknown exit code",
+			checker:         ExecChecker{Command: "sh", Args: []string{"-c", "exit 3"}},
+			expectedSuccess: false,
+		},
+		{
+			name: "env and secrets",
+			checker: ExecChecker{
+				Command:            "sh",
+				Args:               []string{"-c", "echo $GREETING $TOKEN"},
+				Env:                map[string]string{"GREETING": "hello"},
+				SecretEnv:          map[string]string{"TOKEN": "api-token"},
+				CredentialProvider: &credentialprovider.MockCredentialProvider{},
+			},
+			expectedSuccess: true,
+			expectedMessage: "hello mocksecret-api-token",
+		},
+		{
+			name:            "working dir",
+			checker:         ExecChecker{Command: "pwd", WorkingDir: "/"},
+			expectedSuccess: true,
+			expectedMessage: "/",
+		},
+		{
+			name:            "timeout",
+			checker:         ExecChecker{Command: "sleep", Args: []string{"5"}, Timeout: 100 * time.Millisecond},
+			expectedSuccess: false,
+		},
+		{
+			name:            "missing command",
+			checker:         ExecChecker{Command: "/nonexistent/check_disk"},
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := tc.checker
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			var degraded *DegradedError
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedDegraded, errors.As(err, &degraded))
+			assert.Equal(t, tc.expectedMessage, checker.Message())
+		})
+	}
+}
+
+func TestExecChecker_CheckBackgroundChildHoldingOutput(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		checker         ExecChecker
+		expectedSuccess bool
+		expectedMessage string
+	}{
+		{
+			name:            "command exits leaving a child",
+			checker:         ExecChecker{Command: "sh", Args: []string{"-c", "sleep 60 & echo x"}, Timeout: 10 * time.Second},
+			expectedSuccess: true,
+			expectedMessage: "x",
+		},
+		{
+			name:            "command times out with a child",
+			checker:         ExecChecker{Command: "sh", Args: []string{"-c", "sleep 60 & echo x; sleep 60"}, Timeout: 100 * time.Millisecond},
+			expectedSuccess: false,
+			expectedMessage: "x",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := tc.checker
+			start := time.Now()
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Less(t, time.Since(start), 5*time.Second)
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedMessage, checker.Message())
+		})
+	}
+}
+
+func TestExecChecker_Name(t *testing.T) {
+	checker := ExecChecker{Command: "/usr/lib/nagios/plugins/check_disk", Args: []string{"-w", "20%"}}
+	assert.Equal(t, "Exec: /usr/lib/nagios/plugins/check_disk -w 20%", checker.Name())
+}
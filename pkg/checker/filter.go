@@ -0,0 +1,59 @@
+package checker
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Filter selects check results. A result must have all Tags and, for each
+// of Groups, Types and States that is not empty, match one of its values.
+type Filter struct {
+	Tags   []string
+	Groups []string
+	Types  []string
+	States []string
+}
+
+// Validate fails on states that are not one of the State constants.
+func (f Filter) Validate() error {
+	for _, state := range f.States {
+		if _, ok := stateSeverity[state]; !ok {
+			return fmt.Errorf("invalid status %q, expected one of: %s", state, strings.Join(States(), ", "))
+		}
+	}
+	return nil
+}
+
+// Match reports whether the filter selects r.
+func (f Filter) Match(r CheckResult) bool {
+	for _, tag := range f.Tags {
+		if !contains(r.Tags, tag) {
+			return false
+		}
+	}
+	return matchAny(f.Groups, r.Group) && matchAny(f.Types, r.Type) && matchAny(f.States, r.State())
+}
+
+// Apply returns the results selected by the filter.
+func (f Filter) Apply(results []CheckResult) []CheckResult {
+	selected := make([]CheckResult, 0, len(results))
+	for _, r := range results {
+		if f.Match(r) {
+			selected = append(selected, r)
+		}
+	}
+	return selected
+}
+
+func matchAny(values []string, value string) bool {
+	return len(values) == 0 || contains(values, value)
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,70 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilter_Apply(t *testing.T) {
+	results := []CheckResult{
+		{Name: "db", Type: "postgres", Group: "Payments", Tags: []string{"prod", "eu"}},
+		{Name: "api", Type: "http", Group: "Payments", Tags: []string{"prod"}, Status: true},
+		{Name: "cache", Type: "redis", Tags: []string{"eu"}, Degraded: true},
+		{Name: "web", Type: "http", Group: "Web", BlockedBy: []string{"db"}},
+	}
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{
+			name:     "empty filter",
+			filter:   Filter{},
+			expected: []string{"db", "api", "cache", "web"},
+		},
+		{
+			name:     "all tags",
+			filter:   Filter{Tags: []string{"prod", "eu"}},
+			expected: []string{"db"},
+		},
+		{
+			name:     "any group",
+			filter:   Filter{Groups: []string{"Payments", "Web"}},
+			expected: []string{"db", "api", "web"},
+		},
+		{
+			name:     "type",
+			filter:   Filter{Types: []string{"http"}},
+			expected: []string{"api", "web"},
+		},
+		{
+			name:     "states",
+			filter:   Filter{States: []string{StateDegraded, StateBlocked}},
+			expected: []string{"cache", "web"},
+		},
+		{
+			name:     "combined",
+			filter:   Filter{Tags: []string{"prod"}, States: []string{StateUnavailable}},
+			expected: []string{"db"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var names []string
+			for _, r := range tc.filter.Apply(results) {
+				names = append(names, r.Name)
+			}
+			assert.Equal(t, tc.expected, names)
+		})
+	}
+}
+
+func TestFilter_Validate(t *testing.T) {
+	assert.Nil(t, Filter{States: States()}.Validate())
+	assert.EqualError(t, Filter{States: []string{"down"}}.Validate(),
+		`invalid status "down", expected one of: available, degraded, flapping, blocked, unavailable`)
+}
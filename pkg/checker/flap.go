@@ -0,0 +1,49 @@
+package checker
+
+// FlapDetector keeps the states of the last checks of a checker and reports
+// it as flapping while they changed too often, so a checker toggling
+// between states is shown as flapping instead.
+type FlapDetector struct {
+	// Window is the number of states kept and Threshold the number of
+	// changes between them that makes the checker flapping.
+	Window    int
+	Threshold int
+	states    []string
+	flapping  bool
+}
+
+// NewFlapDetector returns a detector over window states. A zero threshold
+// defaults to half the window.
+func NewFlapDetector(window, threshold int) *FlapDetector {
+	if threshold == 0 {
+		threshold = window / 2
+	}
+	return &FlapDetector{Window: window, Threshold: threshold}
+}
+
+// Record adds the state of the last check and reports whether the checker
+// is flapping.
+func (d *FlapDetector) Record(state string) bool {
+	d.states = append(d.states, state)
+	if len(d.states) > d.Window {
+		d.states = d.states[len(d.states)-d.Window:]
+	}
+	d.flapping = d.Changes() >= d.Threshold
+	return d.flapping
+}
+
+// Changes returns the number of state changes among the kept states.
+func (d *FlapDetector) Changes() int {
+	changes := 0
+	for i := 1; i < len(d.states); i++ {
+		if d.states[i] != d.states[i-1] {
+			changes++
+		}
+	}
+	return changes
+}
+
+// Flapping reports whether the checker was flapping after the last Record.
+func (d *FlapDetector) Flapping() bool {
+	return d.flapping
+}
@@ -0,0 +1,54 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlapDetector_Record(t *testing.T) {
+	up, down := StateAvailable, StateUnavailable
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		states   []string
+		expected []bool
+	}{
+		{
+			name:     "stable",
+			states:   []string{up, up, up, up, up},
+			expected: []bool{false, false, false, false, false},
+		},
+		{
+			name:     "single change",
+			states:   []string{up, up, down, down, down},
+			expected: []bool{false, false, false, false, false},
+		},
+		{
+			name:     "toggling",
+			states:   []string{up, down, up, down, down, down, down},
+			expected: []bool{false, false, true, true, true, false, false},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := NewFlapDetector(4, 0)
+
+			var flapping []bool
+			for _, state := range tc.states {
+				flapping = append(flapping, d.Record(state))
+			}
+
+			assert.Equal(t, tc.expected, flapping)
+			assert.Equal(t, tc.expected[len(tc.expected)-1], d.Flapping())
+		})
+	}
+}
+
+func TestCheckResult_State(t *testing.T) {
+	assert.Equal(t, StateFlapping, CheckResult{Status: true, Flapping: true}.State())
+	assert.Equal(t, StateAvailable, CheckResult{Status: true}.State())
+	assert.Equal(t, StateBlocked, CheckResult{BlockedBy: []string{"db"}}.State())
+}
@@ -0,0 +1,45 @@
+package checker
+
+import (
+	"sort"
+)
+
+// Group holds the results sharing a group field along with their rollup:
+// the number of results in each state and the worst of those states.
+type Group struct {
+	Name    string
+	Results []CheckResult
+	State   string
+	Counts  map[string]int
+}
+
+// GroupResults groups results by their group field, keeping their order
+// within each group. Groups are sorted by name, with the results without a
+// group last.
+func GroupResults(results []CheckResult) []Group {
+	var groups []Group
+	index := make(map[string]int)
+	for _, r := range results {
+		i, ok := index[r.Group]
+		if !ok {
+			i = len(groups)
+			index[r.Group] = i
+			groups = append(groups, Group{Name: r.Group, State: StateAvailable, Counts: make(map[string]int)})
+		}
+		g := &groups[i]
+		g.Results = append(g.Results, r)
+		state := r.State()
+		g.Counts[state]++
+		if stateSeverity[state] > stateSeverity[g.State] {
+			g.State = state
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if (groups[i].Name == "") != (groups[j].Name == "") {
+			return groups[j].Name == ""
+		}
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
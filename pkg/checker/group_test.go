@@ -0,0 +1,22 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupResults(t *testing.T) {
+	db := CheckResult{Name: "db", Group: "Payments"}
+	api := CheckResult{Name: "api", Group: "Payments", Status: true}
+	cache := CheckResult{Name: "cache", Degraded: true}
+	web := CheckResult{Name: "web", Group: "Frontend", Status: true}
+
+	groups := GroupResults([]CheckResult{api, cache, db, web})
+
+	assert.Equal(t, []Group{
+		{Name: "Frontend", Results: []CheckResult{web}, State: StateAvailable, Counts: map[string]int{StateAvailable: 1}},
+		{Name: "Payments", Results: []CheckResult{api, db}, State: StateUnavailable, Counts: map[string]int{StateAvailable: 1, StateUnavailable: 1}},
+		{Name: "", Results: []CheckResult{cache}, State: StateDegraded, Counts: map[string]int{StateDegraded: 1}},
+	}, groups)
+}
@@ -0,0 +1,125 @@
+package checker
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+const defaultGrpcTimeout = 10 * time.Second
+
+// GrpcChecker checks a service through the standard gRPC health checking
+// protocol (grpc.health.v1.Health/Check).
+type GrpcChecker struct {
+	Server             string
+	Port               string
+	Service            string
+	TLS                bool
+	InsecureSkipVerify bool
+	Metadata           map[string]string
+	Timeout            time.Duration
+}
+
+func (c *GrpcChecker) Name() string {
+	if c.Service == "" {
+		return fmt.Sprintf("gRPC: %s:%s", c.Server, c.Port)
+	}
+	return fmt.Sprintf("gRPC: %s:%s/%s", c.Server, c.Port, c.Service)
+}
+
+func (c *GrpcChecker) Target() string {
+	return c.Server
+}
+
+func (c *GrpcChecker) Check() (bool, error) {
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultGrpcTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	creds := insecure.NewCredentials()
+	if c.TLS {
+		creds = credentials.NewTLS(&tls.Config{
+			ServerName:         c.Server,
+			InsecureSkipVerify: c.InsecureSkipVerify,
+		})
+	}
+
+	conn, err := grpc.DialContext(ctx, net.JoinHostPort(c.Server, c.Port), grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return false, fmt.Errorf("error dialing grpc server: %v", err)
+	}
+	defer conn.Close()
+
+	if len(c.Metadata) > 0 {
+		ctx = metadata.NewOutgoingContext(ctx, metadata.New(c.Metadata))
+	}
+
+	resp, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{Service: c.Service})
+	if err != nil {
+		return false, err
+	}
+
+	switch resp.GetStatus() {
+	case healthpb.HealthCheckResponse_SERVING:
+		return true, nil
+	case healthpb.HealthCheckResponse_NOT_SERVING:
+		return false, fmt.Errorf("service %q is not serving", c.Service)
+	default:
+		return false, fmt.Errorf("service %q reported status %s", c.Service, resp.GetStatus())
+	}
+}
+
+func (c *GrpcChecker) Fix() error {
+	return nil
+}
+
+func (c *GrpcChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("grpc", newGrpcChecker)
+}
+
+type grpcConfig struct {
+	Server             string            `yaml:"server"`
+	Port               string            `yaml:"port"`
+	Service            string            `yaml:"service"`
+	TLS                bool              `yaml:"tls"`
+	InsecureSkipVerify bool              `yaml:"insecureSkipVerify"`
+	Metadata           map[string]string `yaml:"metadata"`
+	Timeout            time.Duration     `yaml:"timeout"`
+}
+
+func newGrpcChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config grpcConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server, "port": config.Port})
+	if err != nil {
+		return nil, err
+	}
+
+	return &GrpcChecker{
+		Server:             config.Server,
+		Port:               config.Port,
+		Service:            config.Service,
+		TLS:                config.TLS,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+		Metadata:           config.Metadata,
+		Timeout:            config.Timeout,
+	}, nil
+}
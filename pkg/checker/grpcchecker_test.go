@@ -0,0 +1,146 @@
+package checker
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+)
+
+// startHealthServer starts an in-process gRPC health server and returns its
+// host, port and the metadata received by the last call.
+func startHealthServer(t *testing.T) (*health.Server, string, string, *metadata.MD) {
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+
+	received := &metadata.MD{}
+	interceptor := func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			*received = md
+		}
+		return handler(ctx, req)
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(interceptor))
+	healthSrv := health.NewServer()
+	healthpb.RegisterHealthServer(srv, healthSrv)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	host, port, _ := net.SplitHostPort(lis.Addr().String())
+	return healthSrv, host, port, received
+}
+
+func TestGrpcChecker_Check(t *testing.T) {
+	healthSrv, host, port, _ := startHealthServer(t)
+	healthSrv.SetServingStatus("serving", healthpb.HealthCheckResponse_SERVING)
+	healthSrv.SetServingStatus("not-serving", healthpb.HealthCheckResponse_NOT_SERVING)
+	healthSrv.SetServingStatus("unknown", healthpb.HealthCheckResponse_UNKNOWN)
+
+	// Test cases
+	testCases := []struct {
+		name           string
+		service        string
+		expectedResult bool
+		expectErr      bool
+	}{
+		{
+			name:           "overall server health",
+			service:        "",
+			expectedResult: true,
+			expectErr:      false,
+		},
+		{
+			name:           "serving service",
+			service:        "serving",
+			expectedResult: true,
+			expectErr:      false,
+		},
+		{
+			name:           "not serving service",
+			service:        "not-serving",
+			expectedResult: false,
+			expectErr:      true,
+		},
+		{
+			name:           "unknown status",
+			service:        "unknown",
+			expectedResult: false,
+			expectErr:      true,
+		},
+		{
+			name:           "unregistered service",
+			service:        "missing",
+			expectedResult: false,
+			expectErr:      true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the checker
+			checker := GrpcChecker{
+				Server:  host,
+				Port:    port,
+				Service: tc.service,
+			}
+
+			// Call the method under test
+			result, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedResult, result)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestGrpcChecker_CheckSendsMetadata(t *testing.T) {
+	_, host, port, received := startHealthServer(t)
+
+	checker := GrpcChecker{
+		Server:   host,
+		Port:     port,
+		Metadata: map[string]string{"x-api-key": "secret"},
+	}
+
+	result, err := checker.Check()
+	assert.True(t, result)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"secret"}, received.Get("x-api-key"))
+}
+
+func TestGrpcChecker_CheckUnreachable(t *testing.T) {
+	checker := GrpcChecker{
+		Server: "127.0.0.1",
+		Port:   "1",
+	}
+
+	result, err := checker.Check()
+	assert.False(t, result)
+	assert.NotNil(t, err)
+}
+
+func TestGrpcChecker_Name(t *testing.T) {
+	checker := GrpcChecker{
+		Server:  "grpc.local",
+		Port:    "50051",
+		Service: "payments.v1.Payments",
+	}
+	assert.Equal(t, "gRPC: grpc.local:50051/payments.v1.Payments", checker.Name())
+
+	checker.Service = ""
+	assert.Equal(t, "gRPC: grpc.local:50051", checker.Name())
+}
+
+func TestGrpcChecker_IsFixable(t *testing.T) {
+	checker := GrpcChecker{}
+	assert.False(t, checker.IsFixable())
+}
@@ -2,6 +2,7 @@ package checker
 
 import (
 	"net/http"
+	"net/url"
 )
 
 type HttpChecker struct {
@@ -22,6 +23,14 @@ func (c *HttpChecker) Name() string {
 	return c.URL
 }
 
+func (c *HttpChecker) Target() string {
+	u, err := url.Parse(c.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Hostname()
+}
+
 func (c *HttpChecker) Fix() error {
 	return nil
 }
@@ -29,4 +38,26 @@ func (c *HttpChecker) Fix() error {
 
 func (c *HttpChecker) IsFixable() bool {
 	return false
-}
\ No newline at end of file
+}
+
+func init() {
+	Register("http", newHttpChecker)
+}
+
+type httpConfig struct {
+	URL string `yaml:"url"`
+}
+
+func newHttpChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config httpConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"url": config.URL})
+	if err != nil {
+		return nil, err
+	}
+
+	return &HttpChecker{URL: config.URL}, nil
+}
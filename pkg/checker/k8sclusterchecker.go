@@ -0,0 +1,185 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// nodePressureConditions are the node conditions that are unhealthy when
+// true.
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+}
+
+// KubernetesClusterChecker reports cluster health from the API server:
+// node Ready conditions, memory, disk and PID pressure, unschedulable
+// nodes and the API server /readyz status.
+type KubernetesClusterChecker struct {
+	// MinReadyNodes defaults to 1.
+	MinReadyNodes int
+	// The Max*Nodes thresholds bound how many nodes may be not ready,
+	// under pressure or cordoned, zero allows none.
+	MaxNotReadyNodes      int
+	MaxPressureNodes      int
+	MaxUnschedulableNodes int
+	SkipReadyz            bool
+	// Timeout bounds the API requests of a check, 10s by default.
+	Timeout   time.Duration
+	K8sClient k8s.K8sClient
+	details   map[string]string
+}
+
+func (c *KubernetesClusterChecker) Name() string {
+	return "Kubernetes: cluster"
+}
+
+func (c *KubernetesClusterChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultKubernetesTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var failures []string
+	if !c.SkipReadyz {
+		err := c.K8sClient.Readyz(ctx)
+		if err != nil {
+			c.details["readyz"] = "failed"
+			failures = append(failures, fmt.Sprintf("api server not ready: %v", err))
+		} else {
+			c.details["readyz"] = "ok"
+		}
+	}
+
+	nodes, err := c.K8sClient.ListNodes(ctx)
+	if err != nil {
+		failures = append(failures, fmt.Sprintf("error listing nodes: %v", err))
+		return false, errors.New(strings.Join(failures, "; "))
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	var ready int
+	// pressure lists each condition for the detail, while the threshold
+	// applies to pressureNodes
+	var notReady, pressure, pressureNodes, unschedulable []string
+	for _, node := range nodes {
+		if nodeConditionTrue(node, corev1.NodeReady) {
+			ready++
+		} else {
+			notReady = append(notReady, node.Name)
+		}
+		underPressure := false
+		for _, condition := range nodePressureConditions {
+			if nodeConditionTrue(node, condition) {
+				pressure = append(pressure, fmt.Sprintf("%s(%s)", node.Name, condition))
+				underPressure = true
+			}
+		}
+		if underPressure {
+			pressureNodes = append(pressureNodes, node.Name)
+		}
+		if node.Spec.Unschedulable {
+			unschedulable = append(unschedulable, node.Name)
+		}
+	}
+
+	c.details["nodes"] = strconv.Itoa(len(nodes))
+	c.details["ready_nodes"] = strconv.Itoa(ready)
+	setListDetail(c.details, "not_ready_nodes", notReady)
+	setListDetail(c.details, "pressure", pressure)
+	setListDetail(c.details, "unschedulable_nodes", unschedulable)
+
+	minReady := c.MinReadyNodes
+	if minReady == 0 {
+		minReady = 1
+	}
+	if ready < minReady {
+		failures = append(failures, fmt.Sprintf("%d ready nodes, expected at least %d", ready, minReady))
+	}
+	if len(notReady) > c.MaxNotReadyNodes {
+		failures = append(failures, fmt.Sprintf("nodes not ready: %s", strings.Join(notReady, ",")))
+	}
+	if len(pressureNodes) > c.MaxPressureNodes {
+		failures = append(failures, fmt.Sprintf("nodes under pressure: %s", strings.Join(pressure, ",")))
+	}
+	if len(unschedulable) > c.MaxUnschedulableNodes {
+		failures = append(failures, fmt.Sprintf("unschedulable nodes: %s", strings.Join(unschedulable, ",")))
+	}
+
+	if len(failures) > 0 {
+		return false, errors.New(strings.Join(failures, "; "))
+	}
+
+	return true, nil
+}
+
+func nodeConditionTrue(node corev1.Node, conditionType corev1.NodeConditionType) bool {
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+func setListDetail(details map[string]string, key string, values []string) {
+	if len(values) > 0 {
+		details[key] = strings.Join(values, ",")
+	}
+}
+
+func (c *KubernetesClusterChecker) Details() map[string]string {
+	return c.details
+}
+
+func (c *KubernetesClusterChecker) Fix() error {
+	return nil
+}
+
+func (c *KubernetesClusterChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("kubernetesCluster", newKubernetesClusterChecker)
+}
+
+type kubernetesClusterConfig struct {
+	MinReadyNodes         int           `yaml:"minReadyNodes"`
+	MaxNotReadyNodes      int           `yaml:"maxNotReadyNodes"`
+	MaxPressureNodes      int           `yaml:"maxPressureNodes"`
+	MaxUnschedulableNodes int           `yaml:"maxUnschedulableNodes"`
+	SkipReadyz            bool          `yaml:"skipReadyz"`
+	Timeout               time.Duration `yaml:"timeout"`
+}
+
+func newKubernetesClusterChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config kubernetesClusterConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubernetesClusterChecker{
+		MinReadyNodes:         config.MinReadyNodes,
+		MaxNotReadyNodes:      config.MaxNotReadyNodes,
+		MaxPressureNodes:      config.MaxPressureNodes,
+		MaxUnschedulableNodes: config.MaxUnschedulableNodes,
+		SkipReadyz:            config.SkipReadyz,
+		Timeout:               config.Timeout,
+		K8sClient:             *deps.K8sClient,
+	}, nil
+}
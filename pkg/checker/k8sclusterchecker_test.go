@@ -0,0 +1,217 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/fake"
+	"k8s.io/client-go/rest"
+)
+
+func testNode(name string, ready bool, unschedulable bool, pressure ...corev1.NodeConditionType) *corev1.Node {
+	readyStatus := corev1.ConditionFalse
+	if ready {
+		readyStatus = corev1.ConditionTrue
+	}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name},
+		Spec:       corev1.NodeSpec{Unschedulable: unschedulable},
+		Status: corev1.NodeStatus{Conditions: []corev1.NodeCondition{
+			{Type: corev1.NodeReady, Status: readyStatus},
+		}},
+	}
+	for _, condition := range pressure {
+		node.Status.Conditions = append(node.Status.Conditions, corev1.NodeCondition{Type: condition, Status: corev1.ConditionTrue})
+	}
+	return node
+}
+
+func TestKubernetesClusterChecker_Check(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		checker         KubernetesClusterChecker
+		objects         []runtime.Object
+		expectedSuccess bool
+	}{
+		{
+			name:            "healthy cluster",
+			checker:         KubernetesClusterChecker{MinReadyNodes: 3},
+			objects:         []runtime.Object{testNode("node-1", true, false), testNode("node-2", true, false), testNode("node-3", true, false)},
+			expectedSuccess: true,
+		},
+		{
+			name:            "too few ready nodes",
+			checker:         KubernetesClusterChecker{MinReadyNodes: 3, MaxNotReadyNodes: 1},
+			objects:         []runtime.Object{testNode("node-1", true, false), testNode("node-2", true, false), testNode("node-3", false, false)},
+			expectedSuccess: false,
+		},
+		{
+			name:            "not ready node within threshold",
+			checker:         KubernetesClusterChecker{MinReadyNodes: 2, MaxNotReadyNodes: 1},
+			objects:         []runtime.Object{testNode("node-1", true, false), testNode("node-2", true, false), testNode("node-3", false, false)},
+			expectedSuccess: true,
+		},
+		{
+			name:            "node under disk pressure",
+			checker:         KubernetesClusterChecker{},
+			objects:         []runtime.Object{testNode("node-1", true, false, corev1.NodeDiskPressure)},
+			expectedSuccess: false,
+		},
+		{
+			name:            "pressure within threshold",
+			checker:         KubernetesClusterChecker{MaxPressureNodes: 1},
+			objects:         []runtime.Object{testNode("node-1", true, false, corev1.NodeMemoryPressure), testNode("node-2", true, false)},
+			expectedSuccess: true,
+		},
+		{
+			name:            "node with several pressure conditions counted once",
+			checker:         KubernetesClusterChecker{MaxPressureNodes: 1},
+			objects:         []runtime.Object{testNode("node-1", true, false, corev1.NodeMemoryPressure, corev1.NodeDiskPressure), testNode("node-2", true, false)},
+			expectedSuccess: true,
+		},
+		{
+			name:            "pressure over threshold",
+			checker:         KubernetesClusterChecker{MaxPressureNodes: 1},
+			objects:         []runtime.Object{testNode("node-1", true, false, corev1.NodeMemoryPressure), testNode("node-2", true, false, corev1.NodeDiskPressure)},
+			expectedSuccess: false,
+		},
+		{
+			name:            "cordoned node",
+			checker:         KubernetesClusterChecker{},
+			objects:         []runtime.Object{testNode("node-1", true, false), testNode("node-2", true, true)},
+			expectedSuccess: false,
+		},
+		{
+			name:            "no nodes",
+			checker:         KubernetesClusterChecker{},
+			objects:         nil,
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// The fake clientset has no REST client to query /readyz
+			checker := tc.checker
+			checker.SkipReadyz = true
+			checker.K8sClient = *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(tc.objects...))
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+		})
+	}
+}
+
+func TestKubernetesClusterChecker_Readyz(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		readyzStatus    int
+		expectedSuccess bool
+		expectedReadyz  string
+	}{
+		{
+			name:            "api server ready",
+			readyzStatus:    http.StatusOK,
+			expectedSuccess: true,
+			expectedReadyz:  "ok",
+		},
+		{
+			name:            "api server not ready",
+			readyzStatus:    http.StatusInternalServerError,
+			expectedSuccess: false,
+			expectedReadyz:  "failed",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Serve /readyz and the node list from a fake API server
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/readyz":
+					w.WriteHeader(tc.readyzStatus)
+				case "/api/v1/nodes":
+					w.Header().Set("Content-Type", "application/json")
+					json.NewEncoder(w).Encode(corev1.NodeList{
+						TypeMeta: metav1.TypeMeta{Kind: "NodeList", APIVersion: "v1"},
+						Items:    []corev1.Node{*testNode("node-1", true, false)},
+					})
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+			assert.Nil(t, err)
+			checker := KubernetesClusterChecker{K8sClient: *k8s.NewK8sClientFromClientset(clientset)}
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedReadyz, checker.Details()["readyz"])
+			assert.Equal(t, "1", checker.Details()["ready_nodes"])
+		})
+	}
+}
+
+// hungK8sClient returns a client of an API server that never answers.
+func hungK8sClient(t *testing.T) k8s.K8sClient {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	t.Cleanup(server.Close)
+	clientset, err := kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	assert.Nil(t, err)
+	return *k8s.NewK8sClientFromClientset(clientset)
+}
+
+func TestKubernetesClusterChecker_BoundedByTimeout(t *testing.T) {
+	checker := KubernetesClusterChecker{Timeout: 200 * time.Millisecond, K8sClient: hungK8sClient(t)}
+	start := time.Now()
+
+	success, err := checker.Check()
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.False(t, success)
+	assert.ErrorContains(t, err, "error listing nodes")
+	assert.Equal(t, "failed", checker.Details()["readyz"])
+}
+
+func TestKubernetesClusterChecker_Details(t *testing.T) {
+	checker := KubernetesClusterChecker{
+		SkipReadyz: true,
+		K8sClient: *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(
+			testNode("node-1", true, false),
+			testNode("node-2", false, true, corev1.NodePIDPressure),
+		)),
+	}
+
+	checker.Check()
+
+	assert.Equal(t, map[string]string{
+		"nodes":               "2",
+		"ready_nodes":         "1",
+		"not_ready_nodes":     "node-2",
+		"pressure":            "node-2(PIDPressure)",
+		"unschedulable_nodes": "node-2",
+	}, checker.Details())
+}
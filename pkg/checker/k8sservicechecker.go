@@ -0,0 +1,280 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	discoveryv1 "k8s.io/api/discovery/v1"
+)
+
+const (
+	defaultKubernetesServiceTimeout = 5 * time.Second
+	maxParallelProbes               = 10
+)
+
+// KubernetesServiceChecker verifies a Service has at least MinReadyEndpoints
+// ready endpoints in its EndpointSlices. With Probe set to "tcp" or "http"
+// every ready endpoint is also probed directly, so a single bad pod behind
+// the Service shows up even when the Service as a whole still answers.
+type KubernetesServiceChecker struct {
+	Namespace string
+	Service   string
+	// MinReadyEndpoints defaults to 1.
+	MinReadyEndpoints int
+	// Probe is "", "tcp" or "http".
+	Probe string
+	// Port is the endpoint port name or number to probe, the first port
+	// of each EndpointSlice is used when empty.
+	Port string
+	Path string
+	// Timeout bounds the API requests and then, separately, the probes of
+	// all endpoints together, 5s by default.
+	Timeout   time.Duration
+	K8sClient k8s.K8sClient
+	details   map[string]string
+}
+
+func (c *KubernetesServiceChecker) Name() string {
+	return fmt.Sprintf("Kubernetes: Service %s/%s", c.namespace(), c.Service)
+}
+
+func (c *KubernetesServiceChecker) namespace() string {
+	if c.Namespace == "" {
+		return "default"
+	}
+	return c.Namespace
+}
+
+func (c *KubernetesServiceChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
+	slices, err := c.endpointSlices()
+	if err != nil {
+		return false, err
+	}
+
+	// Endpoints are keyed by address and port, so that an address serving
+	// several slices of a multi-port Service is counted once per port. An
+	// endpoint ready in any slice is ready.
+	var failures []string
+	ready := make(map[string]bool)
+	notReady := make(map[string]bool)
+	for _, slice := range slices {
+		port, portErr := c.endpointPort(slice)
+		for _, endpoint := range slice.Endpoints {
+			for _, address := range endpoint.Addresses {
+				key := net.JoinHostPort(address, port)
+				if !isEndpointReady(endpoint) {
+					notReady[key] = true
+					continue
+				}
+				if c.Probe != "" && portErr != nil {
+					failures = append(failures, fmt.Sprintf("endpoint %s: %v", address, portErr))
+					continue
+				}
+				ready[key] = true
+			}
+		}
+	}
+	for key := range ready {
+		delete(notReady, key)
+	}
+
+	minReady := c.MinReadyEndpoints
+	if minReady == 0 {
+		minReady = 1
+	}
+	c.details["ready_endpoints"] = strconv.Itoa(len(ready))
+	c.details["not_ready_endpoints"] = strconv.Itoa(len(notReady))
+	if len(ready) < minReady {
+		failures = append(failures, fmt.Sprintf("%d ready endpoints, expected at least %d", len(ready), minReady))
+	}
+
+	if c.Probe != "" {
+		targets := make([]string, 0, len(ready))
+		for target := range ready {
+			targets = append(targets, target)
+		}
+		sort.Strings(targets)
+
+		var failed []string
+		for i, err := range c.probeAll(targets) {
+			if err != nil {
+				failed = append(failed, targets[i])
+				failures = append(failures, fmt.Sprintf("endpoint %s: %v", targets[i], err))
+			}
+		}
+		if len(failed) > 0 {
+			c.details["failed_endpoints"] = strings.Join(failed, ",")
+		}
+	}
+
+	if len(failures) > 0 {
+		return false, errors.New(strings.Join(failures, "; "))
+	}
+
+	return true, nil
+}
+
+// endpointSlices returns the EndpointSlices of the Service, failing when
+// the Service doesn't exist.
+func (c *KubernetesServiceChecker) endpointSlices() ([]discoveryv1.EndpointSlice, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	_, err := c.K8sClient.GetService(ctx, c.namespace(), c.Service)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.K8sClient.ListEndpointSlices(ctx, c.namespace(), c.Service)
+}
+
+func (c *KubernetesServiceChecker) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return defaultKubernetesServiceTimeout
+	}
+	return c.Timeout
+}
+
+// probeAll probes the targets concurrently, at most maxParallelProbes at a
+// time, and returns their errors in order. All probes share the checker
+// timeout so a Service with many dead endpoints doesn't hold up the check
+// cycle, targets not probed in time fail with the deadline error.
+func (c *KubernetesServiceChecker) probeAll(targets []string) []error {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout())
+	defer cancel()
+
+	errs := make([]error, len(targets))
+	semaphore := make(chan struct{}, maxParallelProbes)
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target string) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+				defer func() { <-semaphore }()
+				errs[i] = c.probe(ctx, target)
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+			}
+		}(i, target)
+	}
+	wg.Wait()
+	return errs
+}
+
+// isEndpointReady treats an unknown ready condition as ready, as
+// recommended by the EndpointSlice API.
+func isEndpointReady(endpoint discoveryv1.Endpoint) bool {
+	return endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready
+}
+
+func (c *KubernetesServiceChecker) endpointPort(slice discoveryv1.EndpointSlice) (string, error) {
+	for _, port := range slice.Ports {
+		if port.Port == nil {
+			continue
+		}
+		number := strconv.Itoa(int(*port.Port))
+		if c.Port == "" || c.Port == number || (port.Name != nil && *port.Name == c.Port) {
+			return number, nil
+		}
+	}
+	if c.Port == "" {
+		return "", errors.New("no port to probe")
+	}
+	return "", fmt.Errorf("port %s not found", c.Port)
+}
+
+func (c *KubernetesServiceChecker) probe(ctx context.Context, target string) error {
+	switch c.Probe {
+	case "tcp":
+		var dialer net.Dialer
+		conn, err := dialer.DialContext(ctx, "tcp", target)
+		if err != nil {
+			return err
+		}
+		return conn.Close()
+	case "http":
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("http://%s/%s", target, strings.TrimPrefix(c.Path, "/")), nil)
+		if err != nil {
+			return err
+		}
+		// Pods come and go, don't keep connections to them
+		req.Close = true
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown probe %q", c.Probe)
+	}
+}
+
+func (c *KubernetesServiceChecker) Details() map[string]string {
+	return c.details
+}
+
+func (c *KubernetesServiceChecker) Fix() error {
+	return nil
+}
+
+func (c *KubernetesServiceChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("kubernetesService", newKubernetesServiceChecker)
+}
+
+type kubernetesServiceConfig struct {
+	Namespace         string        `yaml:"namespace"`
+	Service           string        `yaml:"service"`
+	MinReadyEndpoints int           `yaml:"minReadyEndpoints"`
+	Probe             string        `yaml:"probe"`
+	Port              string        `yaml:"port"`
+	Path              string        `yaml:"path"`
+	Timeout           time.Duration `yaml:"timeout"`
+}
+
+func newKubernetesServiceChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config kubernetesServiceConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"service": config.Service})
+	if err != nil {
+		return nil, err
+	}
+	err = oneOf("probe", config.Probe, "", "tcp", "http")
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubernetesServiceChecker{
+		Namespace:         config.Namespace,
+		Service:           config.Service,
+		MinReadyEndpoints: config.MinReadyEndpoints,
+		Probe:             config.Probe,
+		Port:              config.Port,
+		Path:              config.Path,
+		Timeout:           config.Timeout,
+		K8sClient:         *deps.K8sClient,
+	}, nil
+}
@@ -0,0 +1,275 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+var testService = &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"}}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
+
+// testEndpointSlice builds an EndpointSlice for the web Service with an
+// "http" port, ready maps each endpoint address to its ready condition.
+func testEndpointSlice(name string, port int32, ready map[string]*bool) *discoveryv1.EndpointSlice {
+	portName := "http"
+	slice := &discoveryv1.EndpointSlice{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Labels:    map[string]string{discoveryv1.LabelServiceName: "web"},
+		},
+		AddressType: discoveryv1.AddressTypeIPv4,
+		Ports:       []discoveryv1.EndpointPort{{Name: &portName, Port: &port}},
+	}
+	for address, isReady := range ready {
+		slice.Endpoints = append(slice.Endpoints, discoveryv1.Endpoint{
+			Addresses:  []string{address},
+			Conditions: discoveryv1.EndpointConditions{Ready: isReady},
+		})
+	}
+	return slice
+}
+
+func testServerPort(t *testing.T, server *httptest.Server) int32 {
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	assert.Nil(t, err)
+	number, err := strconv.Atoi(port)
+	assert.Nil(t, err)
+	return int32(number)
+}
+
+func TestKubernetesServiceChecker_Check(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	unhealthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer unhealthy.Close()
+
+	// A listener closed right away gives a port nothing listens on
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.Nil(t, err)
+	closedPort := int32(listener.Addr().(*net.TCPAddr).Port)
+	listener.Close()
+
+	healthyPort := testServerPort(t, healthy)
+	unhealthyPort := testServerPort(t, unhealthy)
+
+	// Test cases
+	testCases := []struct {
+		name            string
+		checker         KubernetesServiceChecker
+		objects         []runtime.Object
+		expectedSuccess bool
+	}{
+		{
+			name:    "ready endpoints",
+			checker: KubernetesServiceChecker{MinReadyEndpoints: 2},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", 80, map[string]*bool{
+				"10.0.0.1": boolPtr(true),
+				"10.0.0.2": nil,
+				"10.0.0.3": boolPtr(false),
+			})},
+			expectedSuccess: true,
+		},
+		{
+			name:    "too few ready endpoints",
+			checker: KubernetesServiceChecker{MinReadyEndpoints: 2},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", 80, map[string]*bool{
+				"10.0.0.1": boolPtr(true),
+				"10.0.0.2": boolPtr(false),
+			})},
+			expectedSuccess: false,
+		},
+		{
+			name:            "no endpoints",
+			checker:         KubernetesServiceChecker{},
+			objects:         []runtime.Object{testService},
+			expectedSuccess: false,
+		},
+		{
+			name:            "missing service",
+			checker:         KubernetesServiceChecker{},
+			objects:         nil,
+			expectedSuccess: false,
+		},
+		{
+			name:    "http probe succeeds",
+			checker: KubernetesServiceChecker{Probe: "http", Port: "http", Path: "/healthz"},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", healthyPort, map[string]*bool{
+				"127.0.0.1": boolPtr(true),
+			})},
+			expectedSuccess: true,
+		},
+		{
+			name:    "http probe fails on one endpoint",
+			checker: KubernetesServiceChecker{Probe: "http", Port: "http"},
+			objects: []runtime.Object{
+				testService,
+				testEndpointSlice("web-a", healthyPort, map[string]*bool{"127.0.0.1": boolPtr(true)}),
+				testEndpointSlice("web-b", unhealthyPort, map[string]*bool{"127.0.0.1": boolPtr(true)}),
+			},
+			expectedSuccess: false,
+		},
+		{
+			name:    "tcp probe succeeds",
+			checker: KubernetesServiceChecker{Probe: "tcp"},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", healthyPort, map[string]*bool{
+				"127.0.0.1": boolPtr(true),
+			})},
+			expectedSuccess: true,
+		},
+		{
+			name:    "tcp probe fails",
+			checker: KubernetesServiceChecker{Probe: "tcp"},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", closedPort, map[string]*bool{
+				"127.0.0.1": boolPtr(true),
+			})},
+			expectedSuccess: false,
+		},
+		{
+			name:    "probe port not found",
+			checker: KubernetesServiceChecker{Probe: "tcp", Port: "grpc"},
+			objects: []runtime.Object{testService, testEndpointSlice("web-a", healthyPort, map[string]*bool{
+				"127.0.0.1": boolPtr(true),
+			})},
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the checker with a fake clientset
+			checker := tc.checker
+			checker.Service = "web"
+			checker.K8sClient = *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(tc.objects...))
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+		})
+	}
+}
+
+func TestKubernetesServiceChecker_Details(t *testing.T) {
+	checker := KubernetesServiceChecker{
+		Service: "web",
+		K8sClient: *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(testService, testEndpointSlice("web-a", 80, map[string]*bool{
+			"10.0.0.1": boolPtr(true),
+			"10.0.0.2": boolPtr(false),
+		}))),
+	}
+
+	checker.Check()
+
+	assert.Equal(t, map[string]string{"ready_endpoints": "1", "not_ready_endpoints": "1"}, checker.Details())
+}
+
+func TestKubernetesServiceChecker_CountsEndpointsByAddressAndPort(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name             string
+		objects          []runtime.Object
+		expectedReady    string
+		expectedNotReady string
+	}{
+		{
+			name: "same address on two ports",
+			objects: []runtime.Object{
+				testService,
+				testEndpointSlice("web-a", 80, map[string]*bool{"10.0.0.1": boolPtr(true)}),
+				testEndpointSlice("web-b", 8080, map[string]*bool{"10.0.0.1": boolPtr(false)}),
+			},
+			expectedReady:    "1",
+			expectedNotReady: "1",
+		},
+		{
+			name: "ready in one slice and not ready in another",
+			objects: []runtime.Object{
+				testService,
+				testEndpointSlice("web-a", 80, map[string]*bool{"10.0.0.1": boolPtr(true)}),
+				testEndpointSlice("web-b", 80, map[string]*bool{"10.0.0.1": boolPtr(false), "10.0.0.2": boolPtr(true)}),
+			},
+			expectedReady:    "2",
+			expectedNotReady: "0",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := KubernetesServiceChecker{
+				Service:   "web",
+				K8sClient: *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(tc.objects...)),
+			}
+
+			// Call the method under test
+			checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedReady, checker.Details()["ready_endpoints"])
+			assert.Equal(t, tc.expectedNotReady, checker.Details()["not_ready_endpoints"])
+		})
+	}
+}
+
+func TestKubernetesServiceChecker_ProbesBoundedByTimeout(t *testing.T) {
+	// Listeners that never accept leave HTTP probes waiting for a response
+	objects := []runtime.Object{testService}
+	for i := 0; i < 3*maxParallelProbes; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		assert.Nil(t, err)
+		defer listener.Close()
+		port := int32(listener.Addr().(*net.TCPAddr).Port)
+		objects = append(objects, testEndpointSlice("web-"+strconv.Itoa(i), port, map[string]*bool{"127.0.0.1": boolPtr(true)}))
+	}
+	checker := KubernetesServiceChecker{
+		Service:   "web",
+		Probe:     "http",
+		Timeout:   200 * time.Millisecond,
+		K8sClient: *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(objects...)),
+	}
+	start := time.Now()
+
+	success, err := checker.Check()
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.False(t, success)
+	assert.NotNil(t, err)
+	assert.Equal(t, strconv.Itoa(3*maxParallelProbes), checker.Details()["ready_endpoints"])
+}
+
+func TestKubernetesServiceChecker_APIBoundedByTimeout(t *testing.T) {
+	checker := KubernetesServiceChecker{Service: "web", Timeout: 200 * time.Millisecond, K8sClient: hungK8sClient(t)}
+	start := time.Now()
+
+	success, err := checker.Check()
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestKubernetesServiceChecker_Name(t *testing.T) {
+	checker := KubernetesServiceChecker{Namespace: "shop", Service: "checkout"}
+	assert.Equal(t, "Kubernetes: Service shop/checkout", checker.Name())
+}
@@ -0,0 +1,263 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// defaultKubernetesTimeout bounds the API requests of a check of the
+// kubernetes and kubernetesCluster checkers.
+const defaultKubernetesTimeout = 10 * time.Second
+
+// KubernetesChecker verifies a Deployment, StatefulSet or DaemonSet has all
+// of its desired replicas available and updated, that its rollout is not
+// stuck and that none of its pods are crash looping or restarting too often.
+type KubernetesChecker struct {
+	Namespace string
+	// Kind is one of "Deployment", "StatefulSet" or "DaemonSet".
+	Kind     string
+	Workload string
+	// MaxRestarts is the maximum restart count of any container, zero
+	// disables the check.
+	MaxRestarts int32
+	// Timeout bounds the API requests of a check, 10s by default.
+	Timeout   time.Duration
+	K8sClient k8s.K8sClient
+	details   map[string]string
+}
+
+// workloadStatus is the replica status shared by all workload kinds.
+type workloadStatus struct {
+	desired   int32
+	ready     int32
+	available int32
+	updated   int32
+	selector  *metav1.LabelSelector
+	// stuck holds the reason a rollout is not progressing, if any.
+	stuck string
+}
+
+func (c *KubernetesChecker) Name() string {
+	return fmt.Sprintf("Kubernetes: %s %s/%s", c.Kind, c.namespace(), c.Workload)
+}
+
+func (c *KubernetesChecker) namespace() string {
+	if c.Namespace == "" {
+		return "default"
+	}
+	return c.Namespace
+}
+
+func (c *KubernetesChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultKubernetesTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	status, err := c.workloadStatus(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	c.details["desired"] = strconv.Itoa(int(status.desired))
+	c.details["ready"] = strconv.Itoa(int(status.ready))
+	c.details["available"] = strconv.Itoa(int(status.available))
+	c.details["updated"] = strconv.Itoa(int(status.updated))
+
+	var failures []string
+	if status.available < status.desired {
+		failures = append(failures, fmt.Sprintf("%d of %d replicas available", status.available, status.desired))
+	}
+	if status.updated < status.desired {
+		failures = append(failures, fmt.Sprintf("%d of %d replicas updated", status.updated, status.desired))
+	}
+	if status.stuck != "" {
+		c.details["rollout"] = status.stuck
+		failures = append(failures, fmt.Sprintf("rollout stuck: %s", status.stuck))
+	}
+
+	podFailures, err := c.checkPods(ctx, status.selector)
+	if err != nil {
+		return false, err
+	}
+	failures = append(failures, podFailures...)
+
+	if len(failures) > 0 {
+		return false, errors.New(strings.Join(failures, "; "))
+	}
+
+	return true, nil
+}
+
+func (c *KubernetesChecker) workloadStatus(ctx context.Context) (workloadStatus, error) {
+	switch c.Kind {
+	case "Deployment":
+		deployment, err := c.K8sClient.GetDeployment(ctx, c.namespace(), c.Workload)
+		if err != nil {
+			return workloadStatus{}, err
+		}
+		return deploymentStatus(deployment), nil
+	case "StatefulSet":
+		statefulSet, err := c.K8sClient.GetStatefulSet(ctx, c.namespace(), c.Workload)
+		if err != nil {
+			return workloadStatus{}, err
+		}
+		return workloadStatus{
+			desired:   replicasOrDefault(statefulSet.Spec.Replicas),
+			ready:     statefulSet.Status.ReadyReplicas,
+			available: statefulSet.Status.AvailableReplicas,
+			updated:   statefulSet.Status.UpdatedReplicas,
+			selector:  statefulSet.Spec.Selector,
+		}, nil
+	case "DaemonSet":
+		daemonSet, err := c.K8sClient.GetDaemonSet(ctx, c.namespace(), c.Workload)
+		if err != nil {
+			return workloadStatus{}, err
+		}
+		return workloadStatus{
+			desired:   daemonSet.Status.DesiredNumberScheduled,
+			ready:     daemonSet.Status.NumberReady,
+			available: daemonSet.Status.NumberAvailable,
+			updated:   daemonSet.Status.UpdatedNumberScheduled,
+			selector:  daemonSet.Spec.Selector,
+		}, nil
+	default:
+		return workloadStatus{}, fmt.Errorf("unknown workload kind %q", c.Kind)
+	}
+}
+
+func deploymentStatus(deployment *appsv1.Deployment) workloadStatus {
+	status := workloadStatus{
+		desired:   replicasOrDefault(deployment.Spec.Replicas),
+		ready:     deployment.Status.ReadyReplicas,
+		available: deployment.Status.AvailableReplicas,
+		updated:   deployment.Status.UpdatedReplicas,
+		selector:  deployment.Spec.Selector,
+	}
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			status.stuck = condition.Message
+			if status.stuck == "" {
+				status.stuck = condition.Reason
+			}
+		}
+	}
+	return status
+}
+
+func replicasOrDefault(replicas *int32) int32 {
+	if replicas == nil {
+		return 1
+	}
+	return *replicas
+}
+
+// checkPods returns a failure for every pod with a container in
+// CrashLoopBackOff or restarted more than MaxRestarts times.
+func (c *KubernetesChecker) checkPods(ctx context.Context, selector *metav1.LabelSelector) ([]string, error) {
+	if selector == nil {
+		return nil, nil
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid selector: %v", err)
+	}
+
+	pods, err := c.K8sClient.ListPods(ctx, c.namespace(), labelSelector.String())
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(pods, func(i, j int) bool { return pods[i].Name < pods[j].Name })
+
+	var failures []string
+	var crashLooping []string
+	var maxRestarts int32
+	for _, pod := range pods {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.RestartCount > maxRestarts {
+				maxRestarts = status.RestartCount
+			}
+			if isCrashLooping(status) {
+				crashLooping = append(crashLooping, pod.Name)
+				failures = append(failures, fmt.Sprintf("pod %s container %s is in CrashLoopBackOff", pod.Name, status.Name))
+			} else if c.MaxRestarts > 0 && status.RestartCount > c.MaxRestarts {
+				failures = append(failures, fmt.Sprintf("pod %s container %s restarted %d times", pod.Name, status.Name, status.RestartCount))
+			}
+		}
+	}
+
+	c.details["pods"] = strconv.Itoa(len(pods))
+	c.details["max_restarts"] = strconv.Itoa(int(maxRestarts))
+	if len(crashLooping) > 0 {
+		c.details["crash_looping"] = strings.Join(crashLooping, ",")
+	}
+
+	return failures, nil
+}
+
+func isCrashLooping(status corev1.ContainerStatus) bool {
+	return status.State.Waiting != nil && status.State.Waiting.Reason == "CrashLoopBackOff"
+}
+
+func (c *KubernetesChecker) Details() map[string]string {
+	return c.details
+}
+
+func (c *KubernetesChecker) Fix() error {
+	return nil
+}
+
+func (c *KubernetesChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("kubernetes", newKubernetesChecker)
+}
+
+type kubernetesConfig struct {
+	Namespace   string        `yaml:"namespace"`
+	Kind        string        `yaml:"kind"`
+	Workload    string        `yaml:"workload"`
+	MaxRestarts int32         `yaml:"maxRestarts"`
+	Timeout     time.Duration `yaml:"timeout"`
+}
+
+func newKubernetesChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config kubernetesConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"kind": config.Kind, "workload": config.Workload})
+	if err != nil {
+		return nil, err
+	}
+	err = oneOf("kind", config.Kind, "Deployment", "StatefulSet", "DaemonSet")
+	if err != nil {
+		return nil, err
+	}
+
+	return &KubernetesChecker{
+		Namespace:   config.Namespace,
+		Kind:        config.Kind,
+		Workload:    config.Workload,
+		MaxRestarts: config.MaxRestarts,
+		Timeout:     config.Timeout,
+		K8sClient:   *deps.K8sClient,
+	}, nil
+}
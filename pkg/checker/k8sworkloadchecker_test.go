@@ -0,0 +1,190 @@
+package checker
+
+import (
+	"availability-checker/pkg/k8s"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func testDeployment(replicas, available, updated int32, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: int32Ptr(replicas),
+			Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+		Status: appsv1.DeploymentStatus{
+			ReadyReplicas:     available,
+			AvailableReplicas: available,
+			UpdatedReplicas:   updated,
+			Conditions:        conditions,
+		},
+	}
+}
+
+func testPod(name string, restarts int32, waitingReason string) *corev1.Pod {
+	status := corev1.ContainerStatus{Name: "app", RestartCount: restarts}
+	if waitingReason != "" {
+		status.State.Waiting = &corev1.ContainerStateWaiting{Reason: waitingReason}
+	}
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: map[string]string{"app": "web"}},
+		Status:     corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{status}},
+	}
+}
+
+func TestKubernetesChecker_Check(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		kind            string
+		maxRestarts     int32
+		objects         []runtime.Object
+		expectedSuccess bool
+	}{
+		{
+			name:            "healthy deployment",
+			kind:            "Deployment",
+			objects:         []runtime.Object{testDeployment(2, 2, 2), testPod("web-1", 0, ""), testPod("web-2", 1, "")},
+			expectedSuccess: true,
+		},
+		{
+			name:            "deployment missing available replicas",
+			kind:            "Deployment",
+			objects:         []runtime.Object{testDeployment(3, 2, 3)},
+			expectedSuccess: false,
+		},
+		{
+			name:            "deployment rollout in progress",
+			kind:            "Deployment",
+			objects:         []runtime.Object{testDeployment(3, 3, 1)},
+			expectedSuccess: false,
+		},
+		{
+			name: "deployment rollout stuck",
+			kind: "Deployment",
+			objects: []runtime.Object{testDeployment(2, 2, 2, appsv1.DeploymentCondition{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: `ReplicaSet "web-5d8f" has timed out progressing.`,
+			})},
+			expectedSuccess: false,
+		},
+		{
+			name:            "pod in crash loop",
+			kind:            "Deployment",
+			objects:         []runtime.Object{testDeployment(1, 1, 1), testPod("web-1", 7, "CrashLoopBackOff")},
+			expectedSuccess: false,
+		},
+		{
+			name:            "restarts over threshold",
+			kind:            "Deployment",
+			maxRestarts:     5,
+			objects:         []runtime.Object{testDeployment(1, 1, 1), testPod("web-1", 6, "")},
+			expectedSuccess: false,
+		},
+		{
+			name: "healthy statefulset",
+			kind: "StatefulSet",
+			objects: []runtime.Object{&appsv1.StatefulSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Spec:       appsv1.StatefulSetSpec{Replicas: int32Ptr(3)},
+				Status:     appsv1.StatefulSetStatus{ReadyReplicas: 3, AvailableReplicas: 3, UpdatedReplicas: 3},
+			}},
+			expectedSuccess: true,
+		},
+		{
+			name: "daemonset not fully scheduled",
+			kind: "DaemonSet",
+			objects: []runtime.Object{&appsv1.DaemonSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "web", Namespace: "default"},
+				Status:     appsv1.DaemonSetStatus{DesiredNumberScheduled: 3, NumberReady: 2, NumberAvailable: 2, UpdatedNumberScheduled: 3},
+			}},
+			expectedSuccess: false,
+		},
+		{
+			name:            "missing workload",
+			kind:            "Deployment",
+			objects:         nil,
+			expectedSuccess: false,
+		},
+		{
+			name:            "unknown kind",
+			kind:            "CronJob",
+			objects:         nil,
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the checker with a fake clientset
+			checker := KubernetesChecker{
+				Kind:        tc.kind,
+				Workload:    "web",
+				MaxRestarts: tc.maxRestarts,
+				K8sClient:   *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(tc.objects...)),
+			}
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+		})
+	}
+}
+
+func TestKubernetesChecker_BoundedByTimeout(t *testing.T) {
+	checker := KubernetesChecker{Kind: "Deployment", Workload: "web", Timeout: 200 * time.Millisecond, K8sClient: hungK8sClient(t)}
+	start := time.Now()
+
+	success, err := checker.Check()
+
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestKubernetesChecker_Details(t *testing.T) {
+	checker := KubernetesChecker{
+		Kind:      "Deployment",
+		Workload:  "web",
+		K8sClient: *k8s.NewK8sClientFromClientset(fake.NewSimpleClientset(testDeployment(2, 2, 2), testPod("web-1", 0, "CrashLoopBackOff"), testPod("web-2", 3, ""))),
+	}
+
+	checker.Check()
+
+	assert.Equal(t, map[string]string{
+		"desired":       "2",
+		"ready":         "2",
+		"available":     "2",
+		"updated":       "2",
+		"pods":          "2",
+		"max_restarts":  "3",
+		"crash_looping": "web-1",
+	}, checker.Details())
+}
+
+func TestKubernetesChecker_Name(t *testing.T) {
+	checker := KubernetesChecker{Kind: "StatefulSet", Workload: "postgres"}
+	assert.Equal(t, "Kubernetes: StatefulSet default/postgres", checker.Name())
+}
+
+func TestKubernetesChecker_IsFixable(t *testing.T) {
+	checker := KubernetesChecker{}
+	assert.False(t, checker.IsFixable())
+}
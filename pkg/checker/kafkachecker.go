@@ -0,0 +1,291 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/segmentio/kafka-go/sasl"
+	"github.com/segmentio/kafka-go/sasl/plain"
+	"github.com/segmentio/kafka-go/sasl/scram"
+)
+
+const (
+	defaultKafkaTimeout        = 10 * time.Second
+	defaultKafkaMaxConsumerLag = 1000
+)
+
+// KafkaChecker verifies broker metadata is retrievable from a bootstrap
+// list and optionally that topics are healthy and a consumer group is not
+// lagging behind.
+type KafkaChecker struct {
+	Brokers []string
+	// Topics maps each topic that must exist to its expected partition
+	// count, zero accepts any partition count.
+	Topics map[string]int
+	// ConsumerGroup is the group whose lag over Topics is verified, it
+	// requires Topics so the lag isn't summed over every topic of the
+	// cluster.
+	ConsumerGroup  string
+	MaxConsumerLag int64
+	// SASLMechanism is one of "plain", "scram-sha-256" or "scram-sha-512",
+	// empty disables SASL authentication.
+	SASLMechanism      string
+	TLS                bool
+	Timeout            time.Duration
+	CredentialProvider credentialprovider.CredentialProvider
+}
+
+func (c *KafkaChecker) Name() string {
+	return fmt.Sprintf("Kafka: %s", strings.Join(c.Brokers, ","))
+}
+
+func (c *KafkaChecker) saslMechanism() (sasl.Mechanism, error) {
+	if c.SASLMechanism == "" {
+		return nil, nil
+	}
+
+	user, pwd, err := c.CredentialProvider.GetCredentials("kafka")
+	if err != nil {
+		return nil, fmt.Errorf("error getting credentials: %v", err)
+	}
+
+	if user == "" || pwd == "" {
+		return nil, errors.New("empty username or password")
+	}
+
+	switch c.SASLMechanism {
+	case "plain":
+		return plain.Mechanism{Username: user, Password: pwd}, nil
+	case "scram-sha-256":
+		return scram.Mechanism(scram.SHA256, user, pwd)
+	case "scram-sha-512":
+		return scram.Mechanism(scram.SHA512, user, pwd)
+	default:
+		return nil, fmt.Errorf("unknown sasl mechanism %q", c.SASLMechanism)
+	}
+}
+
+func (c *KafkaChecker) Check() (bool, error) {
+	if len(c.Brokers) == 0 {
+		return false, errors.New("no brokers configured")
+	}
+
+	mechanism, err := c.saslMechanism()
+	if err != nil {
+		return false, err
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultKafkaTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	transport := &kafka.Transport{
+		DialTimeout: timeout,
+		SASL:        mechanism,
+	}
+	if c.TLS {
+		transport.TLS = &tls.Config{}
+	}
+	defer transport.CloseIdleConnections()
+
+	client := &kafka.Client{
+		Addr:      kafka.TCP(c.Brokers...),
+		Timeout:   timeout,
+		Transport: transport,
+	}
+
+	metadata, err := client.Metadata(ctx, &kafka.MetadataRequest{Topics: c.topicNames()})
+	if err != nil {
+		return false, err
+	}
+
+	if len(metadata.Brokers) == 0 {
+		return false, errors.New("metadata returned no brokers")
+	}
+
+	err = c.checkTopics(metadata.Topics)
+	if err != nil {
+		return false, err
+	}
+
+	if c.ConsumerGroup == "" {
+		return true, nil
+	}
+
+	partitions := make(map[string][]int)
+	offsetRequests := make(map[string][]kafka.OffsetRequest)
+	for _, topic := range metadata.Topics {
+		for _, p := range topic.Partitions {
+			partitions[topic.Name] = append(partitions[topic.Name], p.ID)
+			offsetRequests[topic.Name] = append(offsetRequests[topic.Name], kafka.FirstOffsetOf(p.ID), kafka.LastOffsetOf(p.ID))
+		}
+	}
+
+	committed, err := client.OffsetFetch(ctx, &kafka.OffsetFetchRequest{GroupID: c.ConsumerGroup, Topics: partitions})
+	if err != nil {
+		return false, fmt.Errorf("error fetching committed offsets: %v", err)
+	}
+
+	offsets, err := client.ListOffsets(ctx, &kafka.ListOffsetsRequest{Topics: offsetRequests})
+	if err != nil {
+		return false, fmt.Errorf("error listing offsets: %v", err)
+	}
+
+	lag, err := consumerLag(committed, offsets)
+	if err != nil {
+		return false, err
+	}
+
+	if lag > c.MaxConsumerLag {
+		return false, fmt.Errorf("consumer group %s lag %d exceeds %d", c.ConsumerGroup, lag, c.MaxConsumerLag)
+	}
+
+	return true, nil
+}
+
+func (c *KafkaChecker) topicNames() []string {
+	names := make([]string, 0, len(c.Topics))
+	for name := range c.Topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// checkTopics verifies every configured topic exists with the expected
+// partition count and no offline partitions.
+func (c *KafkaChecker) checkTopics(topics []kafka.Topic) error {
+	found := make(map[string]kafka.Topic, len(topics))
+	for _, topic := range topics {
+		found[topic.Name] = topic
+	}
+
+	for _, name := range c.topicNames() {
+		topic, ok := found[name]
+		if !ok {
+			return fmt.Errorf("topic %s not found", name)
+		}
+		if topic.Error != nil {
+			return fmt.Errorf("topic %s: %v", name, topic.Error)
+		}
+
+		expected := c.Topics[name]
+		if expected > 0 && len(topic.Partitions) != expected {
+			return fmt.Errorf("topic %s has %d partitions, expected %d", name, len(topic.Partitions), expected)
+		}
+
+		for _, p := range topic.Partitions {
+			if p.Error != nil || p.Leader.Host == "" {
+				return fmt.Errorf("topic %s partition %d is offline", name, p.ID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// consumerLag sums, over every partition, the difference between the last
+// offset and the committed offset of the group. Partitions without a
+// committed offset count from their first offset.
+func consumerLag(committed *kafka.OffsetFetchResponse, offsets *kafka.ListOffsetsResponse) (int64, error) {
+	if committed.Error != nil {
+		return 0, fmt.Errorf("error fetching committed offsets: %v", committed.Error)
+	}
+
+	var lag int64
+	for topic, partitions := range offsets.Topics {
+		commits := make(map[int]int64)
+		for _, p := range committed.Topics[topic] {
+			if p.Error != nil {
+				return 0, fmt.Errorf("topic %s partition %d: %v", topic, p.Partition, p.Error)
+			}
+			commits[p.Partition] = p.CommittedOffset
+		}
+
+		for _, p := range partitions {
+			if p.Error != nil {
+				return 0, fmt.Errorf("topic %s partition %d: %v", topic, p.Partition, p.Error)
+			}
+			offset, ok := commits[p.Partition]
+			if !ok || offset < 0 {
+				offset = p.FirstOffset
+			}
+			if p.LastOffset > offset {
+				lag += p.LastOffset - offset
+			}
+		}
+	}
+
+	return lag, nil
+}
+
+func (c *KafkaChecker) Fix() error {
+	return nil
+}
+
+func (c *KafkaChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("kafka", newKafkaChecker)
+}
+
+type kafkaConfig struct {
+	Brokers        []string       `yaml:"brokers"`
+	Topics         map[string]int `yaml:"topics"`
+	ConsumerGroup  string         `yaml:"consumerGroup"`
+	MaxConsumerLag *int64         `yaml:"maxConsumerLag"`
+	SASLMechanism  string         `yaml:"saslMechanism"`
+	TLS            bool           `yaml:"tls"`
+	Timeout        time.Duration  `yaml:"timeout"`
+}
+
+func newKafkaChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config kafkaConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"brokers": strings.Join(config.Brokers, ",")})
+	if err != nil {
+		return nil, err
+	}
+	if config.ConsumerGroup != "" && len(config.Topics) == 0 {
+		return nil, errors.New("consumerGroup requires topics")
+	}
+	if config.MaxConsumerLag != nil && *config.MaxConsumerLag < 0 {
+		return nil, errors.New("maxConsumerLag can't be negative")
+	}
+	maxConsumerLag := int64(defaultKafkaMaxConsumerLag)
+	if config.MaxConsumerLag != nil {
+		maxConsumerLag = *config.MaxConsumerLag
+	}
+	if config.SASLMechanism != "" {
+		err = oneOf("saslMechanism", config.SASLMechanism, "plain", "scram-sha-256", "scram-sha-512")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &KafkaChecker{
+		Brokers:            config.Brokers,
+		Topics:             config.Topics,
+		ConsumerGroup:      config.ConsumerGroup,
+		MaxConsumerLag:     maxConsumerLag,
+		SASLMechanism:      config.SASLMechanism,
+		TLS:                config.TLS,
+		Timeout:            config.Timeout,
+		CredentialProvider: deps.CredentialProvider,
+	}, nil
+}
@@ -0,0 +1,192 @@
+package checker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKafkaChecker_CheckUnreachable(t *testing.T) {
+	checker := KafkaChecker{
+		Brokers: []string{"127.0.0.1:1"},
+		Timeout: time.Second,
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestKafkaChecker_CheckTopics(t *testing.T) {
+	broker := kafka.Broker{Host: "kafka-0", Port: 9092, ID: 0}
+	topics := []kafka.Topic{
+		{
+			Name: "orders",
+			Partitions: []kafka.Partition{
+				{Topic: "orders", ID: 0, Leader: broker},
+				{Topic: "orders", ID: 1, Leader: broker},
+			},
+		},
+		{
+			Name: "payments",
+			Partitions: []kafka.Partition{
+				{Topic: "payments", ID: 0, Leader: broker},
+				{Topic: "payments", ID: 1},
+			},
+		},
+	}
+
+	// Test cases
+	testCases := []struct {
+		name      string
+		topics    map[string]int
+		expectErr bool
+	}{
+		{
+			name:      "no topics configured",
+			topics:    nil,
+			expectErr: false,
+		},
+		{
+			name:      "topic with any partition count",
+			topics:    map[string]int{"orders": 0},
+			expectErr: false,
+		},
+		{
+			name:      "topic with expected partition count",
+			topics:    map[string]int{"orders": 2},
+			expectErr: false,
+		},
+		{
+			name:      "topic with unexpected partition count",
+			topics:    map[string]int{"orders": 3},
+			expectErr: true,
+		},
+		{
+			name:      "missing topic",
+			topics:    map[string]int{"refunds": 0},
+			expectErr: true,
+		},
+		{
+			name:      "offline partition",
+			topics:    map[string]int{"payments": 2},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := KafkaChecker{Topics: tc.topics}
+			err := checker.checkTopics(topics)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestConsumerLag(t *testing.T) {
+	offsets := &kafka.ListOffsetsResponse{
+		Topics: map[string][]kafka.PartitionOffsets{
+			"orders": {
+				{Partition: 0, FirstOffset: 0, LastOffset: 100},
+				{Partition: 1, FirstOffset: 40, LastOffset: 50},
+			},
+		},
+	}
+
+	// Test cases
+	testCases := []struct {
+		name        string
+		committed   *kafka.OffsetFetchResponse
+		expectedLag int64
+		expectErr   bool
+	}{
+		{
+			name: "caught up",
+			committed: &kafka.OffsetFetchResponse{Topics: map[string][]kafka.OffsetFetchPartition{
+				"orders": {{Partition: 0, CommittedOffset: 100}, {Partition: 1, CommittedOffset: 50}},
+			}},
+			expectedLag: 0,
+		},
+		{
+			name: "lagging",
+			committed: &kafka.OffsetFetchResponse{Topics: map[string][]kafka.OffsetFetchPartition{
+				"orders": {{Partition: 0, CommittedOffset: 90}, {Partition: 1, CommittedOffset: 45}},
+			}},
+			expectedLag: 15,
+		},
+		{
+			name: "partition without committed offset",
+			committed: &kafka.OffsetFetchResponse{Topics: map[string][]kafka.OffsetFetchPartition{
+				"orders": {{Partition: 0, CommittedOffset: 100}, {Partition: 1, CommittedOffset: -1}},
+			}},
+			expectedLag: 10,
+		},
+		{
+			name:      "group error",
+			committed: &kafka.OffsetFetchResponse{Error: errors.New("group coordinator not available")},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			lag, err := consumerLag(tc.committed, offsets)
+			assert.Equal(t, tc.expectedLag, lag)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestKafkaChecker_Name(t *testing.T) {
+	checker := KafkaChecker{Brokers: []string{"kafka-0:9092", "kafka-1:9092"}}
+	assert.Equal(t, "Kafka: kafka-0:9092,kafka-1:9092", checker.Name())
+}
+
+func TestKafkaChecker_IsFixable(t *testing.T) {
+	checker := KafkaChecker{}
+	assert.False(t, checker.IsFixable())
+}
+
+func TestNewKafkaChecker_MaxConsumerLag(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name        string
+		entry       string
+		expectedLag int64
+		expectedErr string
+	}{
+		{
+			name:        "default",
+			entry:       "type: kafka\nbrokers: [kafka-0:9092]\ntopics: {orders: 0}\nconsumerGroup: orders",
+			expectedLag: defaultKafkaMaxConsumerLag,
+		},
+		{
+			name:        "no lag allowed",
+			entry:       "type: kafka\nbrokers: [kafka-0:9092]\ntopics: {orders: 0}\nconsumerGroup: orders\nmaxConsumerLag: 0",
+			expectedLag: 0,
+		},
+		{
+			name:        "negative",
+			entry:       "type: kafka\nbrokers: [kafka-0:9092]\ntopics: {orders: 0}\nconsumerGroup: orders\nmaxConsumerLag: -1",
+			expectedErr: "maxConsumerLag can't be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Call the method under test
+			c, err := newFromYAML(t, tc.entry)
+
+			// Assert the result
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, tc.expectedLag, c.(*KafkaChecker).MaxConsumerLag)
+		})
+	}
+}
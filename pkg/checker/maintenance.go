@@ -0,0 +1,147 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// MaintenanceTarget selects the checkers a maintenance window or silence
+// applies to: those named in Checkers and those with any of Tags.
+type MaintenanceTarget struct {
+	Checkers []string `yaml:"checkers" json:"checkers,omitempty"`
+	Tags     []string `yaml:"tags" json:"tags,omitempty"`
+}
+
+// Selects reports whether the target applies to the checker with the given
+// name and tags.
+func (t MaintenanceTarget) Selects(name string, tags []string) bool {
+	if contains(t.Checkers, name) {
+		return true
+	}
+	for _, tag := range tags {
+		if contains(t.Tags, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t MaintenanceTarget) validate() error {
+	if len(t.Checkers) == 0 && len(t.Tags) == 0 {
+		return errors.New("missing required field(s): checkers or tags")
+	}
+	return nil
+}
+
+// MaintenanceWindow is a planned maintenance from config.yaml. It is either
+// an absolute range from Start to End, or recurring: starting whenever the
+// cron expression in Schedule fires, in local time, and lasting Duration.
+// Checks still run during maintenance, but failures aren't logged as errors
+// and the checkers can't be fixed.
+type MaintenanceWindow struct {
+	Name              string `yaml:"name"`
+	MaintenanceTarget `yaml:",inline"`
+	Start             time.Time     `yaml:"start"`
+	End               time.Time     `yaml:"end"`
+	Schedule          string        `yaml:"schedule"`
+	Duration          time.Duration `yaml:"duration"`
+	cron              *cronSchedule
+}
+
+// Validate checks the window is complete and parses its schedule, it must
+// be called before Active.
+func (w *MaintenanceWindow) Validate() error {
+	err := required(map[string]string{"name": w.Name})
+	if err != nil {
+		return err
+	}
+	err = w.MaintenanceTarget.validate()
+	if err != nil {
+		return err
+	}
+
+	if w.Schedule == "" {
+		if w.Start.IsZero() || w.End.IsZero() {
+			return errors.New("missing required field(s): start and end, or schedule and duration")
+		}
+		if !w.End.After(w.Start) {
+			return errors.New("end must be after start")
+		}
+		return nil
+	}
+
+	if !w.Start.IsZero() || !w.End.IsZero() {
+		return errors.New("start and end can't be combined with schedule")
+	}
+	if w.Duration <= 0 {
+		return errors.New("missing required field(s): duration")
+	}
+	w.cron, err = parseCron(w.Schedule)
+	return err
+}
+
+// Active reports whether the window is in progress at now.
+func (w *MaintenanceWindow) Active(now time.Time) bool {
+	if w.cron == nil {
+		return !now.Before(w.Start) && now.Before(w.End)
+	}
+
+	// Look for a start within the last Duration
+	earliest := now.Add(-w.Duration)
+	for t := now.Truncate(time.Minute); t.After(earliest); t = t.Add(-time.Minute) {
+		if w.cron.Matches(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// Silence is an ad-hoc maintenance created through the API, active from
+// Start to End.
+type Silence struct {
+	ID string `json:"id"`
+	MaintenanceTarget
+	Comment string    `json:"comment,omitempty"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+}
+
+// Validate checks the silence is complete.
+func (s *Silence) Validate() error {
+	err := s.MaintenanceTarget.validate()
+	if err != nil {
+		return err
+	}
+	if s.End.IsZero() {
+		return errors.New("missing required field(s): end or duration")
+	}
+	if !s.End.After(s.Start) {
+		return errors.New("end must be after start")
+	}
+	return nil
+}
+
+// Active reports whether the silence is in progress at now.
+func (s *Silence) Active(now time.Time) bool {
+	return !now.Before(s.Start) && now.Before(s.End)
+}
+
+// Maintenance describes the maintenance windows and silences a checker is
+// under at now, it is empty when there are none.
+func Maintenance(name string, tags []string, windows []MaintenanceWindow, silences []Silence, now time.Time) string {
+	for i := range windows {
+		if windows[i].Selects(name, tags) && windows[i].Active(now) {
+			return windows[i].Name
+		}
+	}
+	for i := range silences {
+		if silences[i].Selects(name, tags) && silences[i].Active(now) {
+			if silences[i].Comment == "" {
+				return fmt.Sprintf("silence %s", silences[i].ID)
+			}
+			return fmt.Sprintf("silence %s: %s", silences[i].ID, silences[i].Comment)
+		}
+	}
+	return ""
+}
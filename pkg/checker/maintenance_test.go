@@ -0,0 +1,118 @@
+package checker
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+)
+
+func TestMaintenanceWindow_Active(t *testing.T) {
+	var windows []MaintenanceWindow
+	err := yaml.UnmarshalStrict([]byte(`
+- name: Database upgrade
+  checkers: [db]
+  start: 2026-11-01T02:00:00Z
+  end: 2026-11-01T04:00:00Z
+- name: Weekly patching
+  tags: [prod]
+  schedule: "0 3 * * sun"
+  duration: 2h
+`), &windows)
+	assert.Nil(t, err)
+	for i := range windows {
+		assert.Nil(t, windows[i].Validate())
+	}
+	upgrade, patching := &windows[0], &windows[1]
+
+	// Test cases
+	testCases := []struct {
+		name     string
+		window   *MaintenanceWindow
+		time     time.Time
+		expected bool
+	}{
+		{name: "before range", window: upgrade, time: time.Date(2026, 11, 1, 1, 59, 0, 0, time.UTC), expected: false},
+		{name: "range start", window: upgrade, time: time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC), expected: true},
+		{name: "range end", window: upgrade, time: time.Date(2026, 11, 1, 4, 0, 0, 0, time.UTC), expected: false},
+		{name: "before schedule", window: patching, time: time.Date(2026, 11, 1, 2, 59, 0, 0, time.Local), expected: false},
+		{name: "schedule start", window: patching, time: time.Date(2026, 11, 1, 3, 0, 0, 0, time.Local), expected: true},
+		{name: "within duration", window: patching, time: time.Date(2026, 11, 1, 4, 59, 59, 0, time.Local), expected: true},
+		{name: "after duration", window: patching, time: time.Date(2026, 11, 1, 5, 0, 0, 0, time.Local), expected: false},
+		{name: "other day", window: patching, time: time.Date(2026, 11, 2, 3, 30, 0, 0, time.Local), expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.window.Active(tc.time))
+		})
+	}
+}
+
+func TestMaintenanceWindow_Validate(t *testing.T) {
+	start := time.Date(2026, 11, 1, 2, 0, 0, 0, time.UTC)
+	target := MaintenanceTarget{Tags: []string{"prod"}}
+
+	// Test cases
+	testCases := []struct {
+		name        string
+		window      MaintenanceWindow
+		expectedErr string
+	}{
+		{
+			name:        "missing name",
+			window:      MaintenanceWindow{MaintenanceTarget: target, Start: start, End: start.Add(time.Hour)},
+			expectedErr: "missing required field(s): name",
+		},
+		{
+			name:        "missing target",
+			window:      MaintenanceWindow{Name: "upgrade", Start: start, End: start.Add(time.Hour)},
+			expectedErr: "missing required field(s): checkers or tags",
+		},
+		{
+			name:        "missing time",
+			window:      MaintenanceWindow{Name: "upgrade", MaintenanceTarget: target},
+			expectedErr: "missing required field(s): start and end, or schedule and duration",
+		},
+		{
+			name:        "end before start",
+			window:      MaintenanceWindow{Name: "upgrade", MaintenanceTarget: target, Start: start, End: start},
+			expectedErr: "end must be after start",
+		},
+		{
+			name:        "missing duration",
+			window:      MaintenanceWindow{Name: "patching", MaintenanceTarget: target, Schedule: "0 3 * * sun"},
+			expectedErr: "missing required field(s): duration",
+		},
+		{
+			name:        "invalid schedule",
+			window:      MaintenanceWindow{Name: "patching", MaintenanceTarget: target, Schedule: "0 3 * *", Duration: time.Hour},
+			expectedErr: `invalid cron expression "0 3 * *", expected 5 fields`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.EqualError(t, tc.window.Validate(), tc.expectedErr)
+		})
+	}
+}
+
+func TestMaintenance(t *testing.T) {
+	now := time.Date(2026, 11, 1, 3, 0, 0, 0, time.UTC)
+	windows := []MaintenanceWindow{{
+		Name:              "Database upgrade",
+		MaintenanceTarget: MaintenanceTarget{Checkers: []string{"db"}},
+		Start:             now.Add(-time.Hour),
+		End:               now.Add(time.Hour),
+	}}
+	silences := []Silence{
+		{ID: "1", MaintenanceTarget: MaintenanceTarget{Tags: []string{"eu"}}, Comment: "deploy", Start: now, End: now.Add(time.Hour)},
+		{ID: "2", MaintenanceTarget: MaintenanceTarget{Checkers: []string{"api"}}, Start: now.Add(-2 * time.Hour), End: now},
+	}
+
+	assert.Equal(t, "Database upgrade", Maintenance("db", nil, windows, silences, now))
+	assert.Equal(t, "silence 1: deploy", Maintenance("cache", []string{"prod", "eu"}, windows, silences, now))
+	assert.Equal(t, "", Maintenance("api", nil, windows, silences, now))
+}
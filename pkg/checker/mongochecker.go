@@ -0,0 +1,219 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/k8s"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const defaultMongoTimeout = 10 * time.Second
+
+// MongoChecker pings a MongoDB server and optionally verifies its replica
+// set has a primary and that secondaries are not lagging behind.
+type MongoChecker struct {
+	Server     string
+	Port       string
+	AuthSource string
+	// ReplicaSet is the replica set name, when empty a direct connection
+	// to Server is used.
+	ReplicaSet string
+	// CheckReplicaSet enables the replSetGetStatus verification.
+	CheckReplicaSet bool
+	// MaxReplicationLag is the maximum allowed lag of a secondary behind the
+	// primary, zero disables the check.
+	MaxReplicationLag time.Duration
+	Timeout           time.Duration
+	// Namespace, Deployment and Replicas identify the Kubernetes workload
+	// scaled by Fix. The checker is only fixable when Deployment is set.
+	Namespace          string
+	Deployment         string
+	Replicas           int32
+	CredentialProvider credentialprovider.CredentialProvider
+	K8sClient          k8s.K8sClient
+}
+
+type replSetMember struct {
+	Name       string    `bson:"name"`
+	StateStr   string    `bson:"stateStr"`
+	OptimeDate time.Time `bson:"optimeDate"`
+}
+
+type replSetStatus struct {
+	Set     string          `bson:"set"`
+	Members []replSetMember `bson:"members"`
+}
+
+func (c *MongoChecker) Name() string {
+	return fmt.Sprintf("MongoDB: %s:%s", c.Server, c.Port)
+}
+
+func (c *MongoChecker) Target() string {
+	return c.Server
+}
+
+func (c *MongoChecker) Check() (bool, error) {
+	user, pwd, err := c.CredentialProvider.GetCredentials("mongodb")
+	if err != nil {
+		return false, fmt.Errorf("error getting credentials: %v", err)
+	}
+
+	if user == "" || pwd == "" {
+		return false, errors.New("empty username or password")
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultMongoTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	authSource := c.AuthSource
+	if authSource == "" {
+		authSource = "admin"
+	}
+
+	opts := options.Client().
+		SetHosts([]string{net.JoinHostPort(c.Server, c.Port)}).
+		SetAuth(options.Credential{Username: user, Password: pwd, AuthSource: authSource}).
+		SetConnectTimeout(timeout).
+		SetServerSelectionTimeout(timeout)
+	if c.ReplicaSet != "" {
+		opts.SetReplicaSet(c.ReplicaSet)
+	} else {
+		opts.SetDirect(true)
+	}
+
+	client, err := mongo.Connect(ctx, opts)
+	if err != nil {
+		fmt.Printf("Error opening connection: %v\n", err)
+		return false, err
+	}
+	defer client.Disconnect(context.Background())
+
+	admin := client.Database("admin")
+	err = admin.RunCommand(ctx, bson.D{{Key: "ping", Value: 1}}).Err()
+	if err != nil {
+		fmt.Printf("Error pinging database: %v\n", err)
+		return false, err
+	}
+
+	if !c.CheckReplicaSet {
+		return true, nil
+	}
+
+	var status replSetStatus
+	err = admin.RunCommand(ctx, bson.D{{Key: "replSetGetStatus", Value: 1}}).Decode(&status)
+	if err != nil {
+		return false, fmt.Errorf("error getting replica set status: %v", err)
+	}
+
+	err = c.checkReplSetStatus(status)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// checkReplSetStatus verifies the replica set has a primary and every
+// secondary is within MaxReplicationLag of it.
+func (c *MongoChecker) checkReplSetStatus(status replSetStatus) error {
+	var primary *replSetMember
+	for i, member := range status.Members {
+		if member.StateStr == "PRIMARY" {
+			primary = &status.Members[i]
+			break
+		}
+	}
+	if primary == nil {
+		return fmt.Errorf("replica set %s has no primary", status.Set)
+	}
+
+	if c.MaxReplicationLag == 0 {
+		return nil
+	}
+
+	for _, member := range status.Members {
+		if member.StateStr != "SECONDARY" {
+			continue
+		}
+		lag := primary.OptimeDate.Sub(member.OptimeDate)
+		if lag > c.MaxReplicationLag {
+			return fmt.Errorf("secondary %s is %s behind primary, exceeds %s", member.Name, lag, c.MaxReplicationLag)
+		}
+	}
+
+	return nil
+}
+
+func (c *MongoChecker) Fix() error {
+	if !c.IsFixable() {
+		return errors.New("no kubernetes deployment configured")
+	}
+	namespace := c.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	replicas := c.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	return c.K8sClient.ScaleDeploymentToDesiredReplicas(namespace, c.Deployment, replicas)
+}
+
+func (c *MongoChecker) IsFixable() bool {
+	return c.Deployment != ""
+}
+
+func init() {
+	Register("mongodb", newMongoChecker)
+}
+
+type mongoConfig struct {
+	Server            string        `yaml:"server"`
+	Port              string        `yaml:"port"`
+	AuthSource        string        `yaml:"authSource"`
+	ReplicaSet        string        `yaml:"replicaSet"`
+	CheckReplicaSet   bool          `yaml:"checkReplicaSet"`
+	MaxReplicationLag time.Duration `yaml:"maxReplicationLag"`
+	Timeout           time.Duration `yaml:"timeout"`
+	Namespace         string        `yaml:"namespace"`
+	Deployment        string        `yaml:"deployment"`
+	Replicas          int32         `yaml:"replicas"`
+}
+
+func newMongoChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config mongoConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server, "port": config.Port})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MongoChecker{
+		Server:             config.Server,
+		Port:               config.Port,
+		AuthSource:         config.AuthSource,
+		ReplicaSet:         config.ReplicaSet,
+		CheckReplicaSet:    config.CheckReplicaSet,
+		MaxReplicationLag:  config.MaxReplicationLag,
+		Timeout:            config.Timeout,
+		Namespace:          config.Namespace,
+		Deployment:         config.Deployment,
+		Replicas:           config.Replicas,
+		CredentialProvider: deps.CredentialProvider,
+		K8sClient:          *deps.K8sClient,
+	}, nil
+}
@@ -0,0 +1,102 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMongoChecker_CheckUnreachable(t *testing.T) {
+	checker := MongoChecker{
+		Server:             "127.0.0.1",
+		Port:               "1",
+		Timeout:            time.Second,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestMongoChecker_CheckReplSetStatus(t *testing.T) {
+	now := time.Now()
+
+	// Test cases
+	testCases := []struct {
+		name      string
+		maxLag    time.Duration
+		members   []replSetMember
+		expectErr bool
+	}{
+		{
+			name: "healthy replica set",
+			members: []replSetMember{
+				{Name: "mongo-0", StateStr: "PRIMARY", OptimeDate: now},
+				{Name: "mongo-1", StateStr: "SECONDARY", OptimeDate: now},
+			},
+			expectErr: false,
+		},
+		{
+			name: "no primary",
+			members: []replSetMember{
+				{Name: "mongo-0", StateStr: "SECONDARY", OptimeDate: now},
+				{Name: "mongo-1", StateStr: "SECONDARY", OptimeDate: now},
+			},
+			expectErr: true,
+		},
+		{
+			name:   "secondary within lag threshold",
+			maxLag: 10 * time.Second,
+			members: []replSetMember{
+				{Name: "mongo-0", StateStr: "PRIMARY", OptimeDate: now},
+				{Name: "mongo-1", StateStr: "SECONDARY", OptimeDate: now.Add(-5 * time.Second)},
+			},
+			expectErr: false,
+		},
+		{
+			name:   "secondary lagging behind",
+			maxLag: 10 * time.Second,
+			members: []replSetMember{
+				{Name: "mongo-0", StateStr: "PRIMARY", OptimeDate: now},
+				{Name: "mongo-1", StateStr: "SECONDARY", OptimeDate: now.Add(-time.Minute)},
+			},
+			expectErr: true,
+		},
+		{
+			name:   "lag ignored for non secondary members",
+			maxLag: 10 * time.Second,
+			members: []replSetMember{
+				{Name: "mongo-0", StateStr: "PRIMARY", OptimeDate: now},
+				{Name: "mongo-1", StateStr: "ARBITER"},
+			},
+			expectErr: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := MongoChecker{MaxReplicationLag: tc.maxLag}
+			err := checker.checkReplSetStatus(replSetStatus{Set: "rs0", Members: tc.members})
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestMongoChecker_Name(t *testing.T) {
+	checker := MongoChecker{
+		Server: "testserver",
+		Port:   "27017",
+	}
+	assert.Equal(t, "MongoDB: testserver:27017", checker.Name())
+}
+
+func TestMongoChecker_IsFixable(t *testing.T) {
+	checker := MongoChecker{}
+	assert.False(t, checker.IsFixable())
+
+	checker.Deployment = "mongodb"
+	assert.True(t, checker.IsFixable())
+}
@@ -0,0 +1,200 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/database"
+	"availability-checker/pkg/k8s"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+
+	_ "github.com/microsoft/go-mssqldb"
+)
+
+type MSSQLChecker struct {
+	Server string
+	Port   string
+	// Instance is the named instance, resolved through SQL Server Browser
+	// when Port is empty.
+	Instance string
+	Database string
+	// Encrypt is one of "true", "false", "disable" or "strict", empty keeps
+	// the driver default.
+	Encrypt                string
+	TrustServerCertificate bool
+	// Namespace, Deployment and Replicas identify the Kubernetes workload
+	// scaled by Fix. The checker is only fixable when Deployment is set.
+	Namespace  string
+	Deployment string
+	Replicas   int32
+	// Queries are run after a successful ping to verify the database is
+	// actually usable.
+	Queries []QueryAssertion
+	// ReuseConnection keeps the connection pool open between checks
+	// instead of opening and closing it every time.
+	ReuseConnection bool
+	// MaxConsecutiveErrors is the number of failed checks in a row after
+	// which a reused pool is re-created, 3 by default.
+	MaxConsecutiveErrors int
+	DBConnection         database.DBConnection
+	CredentialProvider   credentialprovider.CredentialProvider
+	K8sClient            k8s.K8sClient
+	details              map[string]string
+	session              dbSession
+}
+
+func (c *MSSQLChecker) Name() string {
+	if c.Instance != "" {
+		return fmt.Sprintf("MSSQL: %s\\%s", c.Server, c.Instance)
+	}
+	return fmt.Sprintf("MSSQL: %s:%s", c.Server, c.Port)
+}
+
+func (c *MSSQLChecker) Target() string {
+	return c.Server
+}
+
+func (c *MSSQLChecker) connectionString(user, pwd string) string {
+	host := c.Server
+	if c.Port != "" {
+		host = net.JoinHostPort(c.Server, c.Port)
+	}
+
+	query := url.Values{}
+	if c.Database != "" {
+		query.Set("database", c.Database)
+	}
+	if c.Encrypt != "" {
+		query.Set("encrypt", c.Encrypt)
+	}
+	if c.TrustServerCertificate {
+		query.Set("TrustServerCertificate", "true")
+	}
+	query.Set("connection timeout", "10")
+
+	u := &url.URL{
+		Scheme:   "sqlserver",
+		User:     url.UserPassword(user, pwd),
+		Host:     host,
+		Path:     c.Instance,
+		RawQuery: query.Encode(),
+	}
+	return u.String()
+}
+
+func (c *MSSQLChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
+	user, pwd, err := c.CredentialProvider.GetCredentials("mssql")
+	if err != nil {
+		return false, fmt.Errorf("error getting credentials: %v", err)
+	}
+
+	if user == "" || pwd == "" {
+		return false, errors.New("empty username or password")
+	}
+
+	release, err := c.session.connect(c.DBConnection, c.ReuseConnection, c.MaxConsecutiveErrors, "sqlserver", c.connectionString(user, pwd))
+	if err != nil {
+		fmt.Printf("Error opening connection: %v\n", err)
+		return false, err
+	}
+
+	err = c.verify()
+	release(err)
+	c.session.poolDetails(c.DBConnection, c.details)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// verify pings the database and runs the configured assertions.
+func (c *MSSQLChecker) verify() error {
+	err := c.DBConnection.Ping()
+	if err != nil {
+		fmt.Printf("Error pinging database: %v\n", err)
+		return err
+	}
+
+	return checkQueries(c.DBConnection, c.Queries)
+}
+
+func (c *MSSQLChecker) Details() map[string]string {
+	return c.details
+}
+
+func (c *MSSQLChecker) Fix() error {
+	if !c.IsFixable() {
+		return errors.New("no kubernetes deployment configured")
+	}
+	namespace := c.Namespace
+	if namespace == "" {
+		namespace = "default"
+	}
+	replicas := c.Replicas
+	if replicas == 0 {
+		replicas = 1
+	}
+	return c.K8sClient.ScaleDeploymentToDesiredReplicas(namespace, c.Deployment, replicas)
+}
+
+func (c *MSSQLChecker) IsFixable() bool {
+	return c.Deployment != ""
+}
+
+// Close closes the reused connection pool, if any.
+func (c *MSSQLChecker) Close() error {
+	c.session.shutdown(c.DBConnection)
+	return nil
+}
+
+func init() {
+	Register("mssql", newMSSQLChecker)
+}
+
+type mssqlConfig struct {
+	Server                 string           `yaml:"server"`
+	Port                   string           `yaml:"port"`
+	Instance               string           `yaml:"instance"`
+	Database               string           `yaml:"database"`
+	Encrypt                string           `yaml:"encrypt"`
+	TrustServerCertificate bool             `yaml:"trustServerCertificate"`
+	Namespace              string           `yaml:"namespace"`
+	Deployment             string           `yaml:"deployment"`
+	Replicas               int32            `yaml:"replicas"`
+	Queries                []QueryAssertion `yaml:"queries"`
+	Pool                   poolConfig       `yaml:",inline"`
+}
+
+func newMSSQLChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config mssqlConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server})
+	if err != nil {
+		return nil, err
+	}
+
+	return &MSSQLChecker{
+		Server:                 config.Server,
+		Port:                   config.Port,
+		Instance:               config.Instance,
+		Database:               config.Database,
+		Encrypt:                config.Encrypt,
+		TrustServerCertificate: config.TrustServerCertificate,
+		Namespace:              config.Namespace,
+		Deployment:             config.Deployment,
+		Replicas:               config.Replicas,
+		Queries:                config.Queries,
+		ReuseConnection:        config.Pool.ReuseConnection,
+		MaxConsecutiveErrors:   config.Pool.MaxConsecutiveErrors,
+		DBConnection:           config.Pool.connection(),
+		CredentialProvider:     deps.CredentialProvider,
+		K8sClient:              *deps.K8sClient,
+	}, nil
+}
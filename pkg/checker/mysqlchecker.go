@@ -4,25 +4,134 @@ import (
 	"availability-checker/pkg/credentialprovider"
 	"availability-checker/pkg/database"
 	"availability-checker/pkg/k8s"
+	"crypto/sha256"
+	"crypto/tls"
 	"errors"
 	"fmt"
+	"net"
+	"sync/atomic"
+	"time"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 type MySQLChecker struct {
-	Server             string
-	Port               string
-	DBConnection       database.DBConnection
-	CredentialProvider credentialprovider.CredentialProvider
-	K8sClient          k8s.K8sClient
+	Server string
+	Port   string
+	// Queries are run after a successful ping to verify the database is
+	// actually usable.
+	Queries []QueryAssertion
+	Options ConnectionOptions
+	// CheckReplication asserts SHOW REPLICA STATUS reports both replication
+	// threads running.
+	CheckReplication bool
+	// MaxReplicationLag is the maximum Seconds_Behind_Source, zero disables
+	// the check.
+	MaxReplicationLag time.Duration
+	// ExpectedRole is either "primary" or "replica" and is matched against
+	// read_only, empty skips the check.
+	ExpectedRole string
+	// MinClusterSize enables the Galera checks, requiring at least that many
+	// nodes in the cluster and the local node to be synced.
+	MinClusterSize int
+	// MaxConnectionsPercent is the maximum Threads_connected as a percentage
+	// of max_connections, zero disables the check.
+	MaxConnectionsPercent float64
+	// ReuseConnection keeps the connection pool open between checks
+	// instead of opening and closing it every time.
+	ReuseConnection bool
+	// MaxConsecutiveErrors is the number of failed checks in a row after
+	// which a reused pool is re-created, 3 by default.
+	MaxConsecutiveErrors int
+	DBConnection         database.DBConnection
+	CredentialProvider   credentialprovider.CredentialProvider
+	K8sClient            k8s.K8sClient
+	details              map[string]string
+	session              dbSession
+	// tlsConfigID and tlsConfigName identify the custom TLS configuration
+	// of the checker registered in the driver.
+	tlsConfigID   int64
+	tlsConfigName string
 }
 
+// mysqlTLSConfigs numbers the custom TLS configurations, which the driver
+// registers globally by name, so that checkers on the same server with
+// different certificates don't overwrite each other's.
+var mysqlTLSConfigs atomic.Int64
+
 func (c *MySQLChecker) Name() string {
 	return fmt.Sprintf("MySQL: %s:%s", c.Server, c.Port)
 }
 
+func (c *MySQLChecker) Target() string {
+	return c.Server
+}
+
+func (c *MySQLChecker) connectionString(user, pwd string) (string, error) {
+	cfg := mysql.NewConfig()
+	cfg.User = user
+	cfg.Passwd = pwd
+	cfg.Net = "tcp"
+	cfg.Addr = net.JoinHostPort(c.Server, c.Port)
+	cfg.DBName = c.Options.Database
+	cfg.Timeout = c.Options.ConnectTimeout
+	cfg.TLSConfig = c.Options.SSLMode
+	if len(c.Options.Params) > 0 {
+		cfg.Params = make(map[string]string, len(c.Options.Params))
+		for k, v := range c.Options.Params {
+			cfg.Params[k] = v
+		}
+	}
+
+	if c.Options.usesSecrets() || c.Options.usesFiles() {
+		ca, cert, key, err := c.Options.certificates(c.CredentialProvider)
+		if err != nil {
+			return "", err
+		}
+		tlsConfig, err := c.Options.tlsConfig(ca, cert, key, c.Server)
+		if err != nil {
+			return "", err
+		}
+		err = c.registerTLSConfig(tlsConfig, ca, cert, key)
+		if err != nil {
+			return "", err
+		}
+		cfg.TLSConfig = c.tlsConfigName
+	}
+
+	return cfg.FormatDSN(), nil
+}
+
+// registerTLSConfig registers the TLS configuration built from the given
+// certificates in the driver. The driver resolves the configuration only
+// when a pool is opened, so its name includes a hash of the certificates:
+// when they are rotated the data source changes and a reused pool is
+// re-created.
+func (c *MySQLChecker) registerTLSConfig(config *tls.Config, ca, cert, key string) error {
+	if c.tlsConfigID == 0 {
+		c.tlsConfigID = mysqlTLSConfigs.Add(1)
+	}
+	sum := sha256.Sum256([]byte(ca + "\x00" + cert + "\x00" + key))
+	name := fmt.Sprintf("availability-checker-%d-%x", c.tlsConfigID, sum[:8])
+	if name == c.tlsConfigName {
+		return nil
+	}
+
+	err := mysql.RegisterTLSConfig(name, config)
+	if err != nil {
+		return err
+	}
+	// A pool opened with the previous configuration keeps using it
+	if c.tlsConfigName != "" {
+		mysql.DeregisterTLSConfig(c.tlsConfigName)
+	}
+	c.tlsConfigName = name
+	return nil
+}
+
 func (c *MySQLChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
 	user, pwd, err := c.CredentialProvider.GetCredentials("mysql")
 	if err != nil {
 		return false, fmt.Errorf("error getting credentials: %v", err)
@@ -32,24 +141,47 @@ func (c *MySQLChecker) Check() (bool, error) {
 		return false, errors.New("empty username or password")
 	}
 
-	connectionString := fmt.Sprintf("%s:%s@tcp(%s:%s)/", user, pwd, c.Server, c.Port)
+	connectionString, err := c.connectionString(user, pwd)
+	if err != nil {
+		return false, err
+	}
 
-	err = c.DBConnection.Open("mysql", connectionString)
+	release, err := c.session.connect(c.DBConnection, c.ReuseConnection, c.MaxConsecutiveErrors, "mysql", connectionString)
 	if err != nil {
 		fmt.Printf("Error opening connection: %v\n", err)
 		return false, err
 	}
-	defer c.DBConnection.Close()
 
-	err = c.DBConnection.Ping()
+	err = c.verify()
+	release(err)
+	c.session.poolDetails(c.DBConnection, c.details)
 	if err != nil {
-		fmt.Printf("Error pinging database: %v\n", err)
 		return false, err
 	}
 
 	return true, nil
 }
 
+// verify pings the database and runs the configured assertions.
+func (c *MySQLChecker) verify() error {
+	err := c.DBConnection.Ping()
+	if err != nil {
+		fmt.Printf("Error pinging database: %v\n", err)
+		return err
+	}
+
+	err = checkQueries(c.DBConnection, c.Queries)
+	if err != nil {
+		return err
+	}
+
+	return c.checkReplication()
+}
+
+func (c *MySQLChecker) Details() map[string]string {
+	return c.details
+}
+
 func (c *MySQLChecker) Fix() error {
 	return c.K8sClient.ScaleDeploymentToDesiredReplicas("default", "mysql", 1)
 }
@@ -57,3 +189,66 @@ func (c *MySQLChecker) Fix() error {
 func (c *MySQLChecker) IsFixable() bool {
 	return true
 }
+
+// Close closes the reused connection pool, if any, and unregisters the
+// custom TLS configuration.
+func (c *MySQLChecker) Close() error {
+	c.session.shutdown(c.DBConnection)
+	if c.tlsConfigName != "" {
+		mysql.DeregisterTLSConfig(c.tlsConfigName)
+		c.tlsConfigName = ""
+	}
+	return nil
+}
+
+func init() {
+	Register("mysql", newMySQLChecker)
+}
+
+type mysqlConfig struct {
+	Server                string            `yaml:"server"`
+	Port                  string            `yaml:"port"`
+	Queries               []QueryAssertion  `yaml:"queries"`
+	CheckReplication      bool              `yaml:"checkReplication"`
+	MaxReplicationLag     time.Duration     `yaml:"maxReplicationLag"`
+	ExpectedRole          string            `yaml:"expectedRole"`
+	MinClusterSize        int               `yaml:"minClusterSize"`
+	MaxConnectionsPercent float64           `yaml:"maxConnectionsPercent"`
+	Options               ConnectionOptions `yaml:",inline"`
+	Pool                  poolConfig        `yaml:",inline"`
+}
+
+func newMySQLChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config mysqlConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server, "port": config.Port})
+	if err != nil {
+		return nil, err
+	}
+	if config.ExpectedRole != "" {
+		err = oneOf("expectedRole", config.ExpectedRole, "primary", "replica")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &MySQLChecker{
+		Server:                config.Server,
+		Port:                  config.Port,
+		Queries:               config.Queries,
+		Options:               config.Options,
+		CheckReplication:      config.CheckReplication,
+		MaxReplicationLag:     config.MaxReplicationLag,
+		ExpectedRole:          config.ExpectedRole,
+		MinClusterSize:        config.MinClusterSize,
+		MaxConnectionsPercent: config.MaxConnectionsPercent,
+		ReuseConnection:       config.Pool.ReuseConnection,
+		MaxConsecutiveErrors:  config.Pool.MaxConsecutiveErrors,
+		DBConnection:          config.Pool.connection(),
+		CredentialProvider:    deps.CredentialProvider,
+		K8sClient:             *deps.K8sClient,
+	}, nil
+}
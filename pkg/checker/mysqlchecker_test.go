@@ -2,10 +2,12 @@ package checker
 
 import (
 	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/database"
 	"errors"
 	"testing"
 
 	"context"
+	"database/sql"
 	"io"
 
 	"github.com/docker/docker/api/types"
@@ -31,6 +33,16 @@ func (m *mockStruct) Close() error {
 	return m.Called().Error(0)
 }
 
+func (m *mockStruct) Stats() sql.DBStats {
+	return m.Called().Get(0).(sql.DBStats)
+}
+
+func (m *mockStruct) Query(ctx context.Context, query string) (*database.QueryResult, error) {
+	args := m.Called(ctx, query)
+	result, _ := args.Get(0).(*database.QueryResult)
+	return result, args.Error(1)
+}
+
 func TestMySQLChecker_Check(t *testing.T) {
 	// Test cases
 	testCases := []struct {
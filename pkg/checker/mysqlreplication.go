@@ -0,0 +1,177 @@
+package checker
+
+import (
+	"availability-checker/pkg/database"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	mysqlReplicaStatusQuery = "SHOW REPLICA STATUS"
+	// mysqlSlaveStatusQuery is used by servers older than MySQL 8.0.22.
+	mysqlSlaveStatusQuery = "SHOW SLAVE STATUS"
+	mysqlReadOnlyQuery    = "SELECT @@global.read_only"
+	mysqlGaleraQuery      = "SHOW GLOBAL STATUS WHERE Variable_name IN ('wsrep_cluster_size', 'wsrep_local_state_comment')"
+	mysqlThreadsQuery     = "SHOW GLOBAL STATUS LIKE 'Threads_connected'"
+	mysqlMaxConnQuery     = "SELECT @@global.max_connections"
+	mysqlRolePrimary      = "primary"
+	mysqlRoleReplica      = "replica"
+)
+
+// checkReplication runs the configured replication, role, Galera and
+// connection checks, recording what it finds in the checker details. Every
+// enabled check runs even when a previous one fails.
+func (c *MySQLChecker) checkReplication() error {
+	var failures []string
+
+	if c.CheckReplication || c.MaxReplicationLag > 0 {
+		failure, err := c.checkReplicaStatus()
+		if err != nil {
+			return err
+		}
+		if failure != "" {
+			failures = append(failures, failure)
+		}
+	}
+
+	if c.ExpectedRole != "" {
+		result, err := runQuery(c.DBConnection, mysqlReadOnlyQuery)
+		if err != nil {
+			return fmt.Errorf("error getting read_only: %v", err)
+		}
+		readOnly, err := result.Scalar()
+		if err != nil {
+			return fmt.Errorf("error getting read_only: %v", err)
+		}
+		role := mysqlRolePrimary
+		if readOnly == "1" {
+			role = mysqlRoleReplica
+		}
+		c.details["read_only"] = readOnly
+		c.details["role"] = role
+		if role != c.ExpectedRole {
+			failures = append(failures, fmt.Sprintf("expected role %s, got %s", c.ExpectedRole, role))
+		}
+	}
+
+	if c.MinClusterSize > 0 {
+		result, err := runQuery(c.DBConnection, mysqlGaleraQuery)
+		if err != nil {
+			return fmt.Errorf("error getting galera status: %v", err)
+		}
+		status := statusVariables(result)
+		size, err := strconv.Atoi(status["wsrep_cluster_size"])
+		if err != nil {
+			return errors.New("galera status not available, is this a galera node?")
+		}
+		state := status["wsrep_local_state_comment"]
+		c.details["wsrep_cluster_size"] = strconv.Itoa(size)
+		c.details["wsrep_local_state"] = state
+		if size < c.MinClusterSize {
+			failures = append(failures, fmt.Sprintf("galera cluster size %d, expected at least %d", size, c.MinClusterSize))
+		}
+		if state != "Synced" {
+			failures = append(failures, fmt.Sprintf("galera node state is %s", state))
+		}
+	}
+
+	if c.MaxConnectionsPercent > 0 {
+		result, err := runQuery(c.DBConnection, mysqlThreadsQuery)
+		if err != nil {
+			return fmt.Errorf("error getting Threads_connected: %v", err)
+		}
+		connections, err := strconv.Atoi(statusVariables(result)["Threads_connected"])
+		if err != nil {
+			return fmt.Errorf("error parsing Threads_connected: %v", err)
+		}
+		result, err = runQuery(c.DBConnection, mysqlMaxConnQuery)
+		if err != nil {
+			return fmt.Errorf("error getting max_connections: %v", err)
+		}
+		value, err := result.Scalar()
+		if err != nil {
+			return fmt.Errorf("error getting max_connections: %v", err)
+		}
+		max, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("error parsing max_connections: %v", err)
+		}
+		c.details["threads_connected"] = strconv.Itoa(connections)
+		c.details["max_connections"] = strconv.Itoa(max)
+
+		percent := float64(connections) / float64(max) * 100
+		if percent > c.MaxConnectionsPercent {
+			failures = append(failures, fmt.Sprintf("connections at %.2f%% of max_connections, exceeds %.2f%%", percent, c.MaxConnectionsPercent))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+// checkReplicaStatus returns a failure message when the replication threads
+// are not running or the replica lags too far behind its source.
+func (c *MySQLChecker) checkReplicaStatus() (string, error) {
+	result, err := runQuery(c.DBConnection, mysqlReplicaStatusQuery)
+	if err != nil {
+		result, err = runQuery(c.DBConnection, mysqlSlaveStatusQuery)
+		if err != nil {
+			return "", fmt.Errorf("error getting replica status: %v", err)
+		}
+	}
+
+	if len(result.Rows) == 0 {
+		return "replication is not configured", nil
+	}
+
+	ioRunning := columnValue(result, "Replica_IO_Running", "Slave_IO_Running")
+	sqlRunning := columnValue(result, "Replica_SQL_Running", "Slave_SQL_Running")
+	behind := columnValue(result, "Seconds_Behind_Source", "Seconds_Behind_Master")
+	c.details["replica_io_running"] = ioRunning
+	c.details["replica_sql_running"] = sqlRunning
+	c.details["seconds_behind_source"] = behind
+
+	if ioRunning != "Yes" || sqlRunning != "Yes" {
+		return fmt.Sprintf("replication threads not running (IO: %s, SQL: %s)", ioRunning, sqlRunning), nil
+	}
+
+	if c.MaxReplicationLag > 0 {
+		seconds, err := strconv.Atoi(behind)
+		if err != nil {
+			return "replication lag is unknown", nil
+		}
+		lag := time.Duration(seconds) * time.Second
+		if lag > c.MaxReplicationLag {
+			return fmt.Sprintf("replication lag %s exceeds %s", lag, c.MaxReplicationLag), nil
+		}
+	}
+
+	return "", nil
+}
+
+// columnValue returns the value of the first column found in the first row.
+func columnValue(result *database.QueryResult, columns ...string) string {
+	for _, column := range columns {
+		if value, ok := result.Value(0, column); ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// statusVariables maps the Variable_name/Value rows of SHOW STATUS.
+func statusVariables(result *database.QueryResult) map[string]string {
+	variables := make(map[string]string, len(result.Rows))
+	for _, row := range result.Rows {
+		if len(row) >= 2 {
+			variables[row[0]] = row[1]
+		}
+	}
+	return variables
+}
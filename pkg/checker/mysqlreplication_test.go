@@ -0,0 +1,167 @@
+package checker
+
+import (
+	"availability-checker/pkg/database"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func replicaStatusResult(ioRunning, sqlRunning, behind string) *database.QueryResult {
+	return &database.QueryResult{
+		Columns: []string{"Replica_IO_Running", "Replica_SQL_Running", "Seconds_Behind_Source"},
+		Rows:    [][]string{{ioRunning, sqlRunning, behind}},
+	}
+}
+
+func statusResult(variables ...string) *database.QueryResult {
+	result := &database.QueryResult{Columns: []string{"Variable_name", "Value"}}
+	for i := 0; i+1 < len(variables); i += 2 {
+		result.Rows = append(result.Rows, []string{variables[i], variables[i+1]})
+	}
+	return result
+}
+
+func TestMySQLChecker_CheckReplication(t *testing.T) {
+	type queryResult struct {
+		result *database.QueryResult
+		err    error
+	}
+
+	// Test cases
+	testCases := []struct {
+		name            string
+		checker         MySQLChecker
+		queries         map[string]queryResult
+		expectErr       bool
+		expectedDetails map[string]string
+	}{
+		{
+			name:            "no replication checks",
+			checker:         MySQLChecker{},
+			queries:         map[string]queryResult{},
+			expectErr:       false,
+			expectedDetails: map[string]string{},
+		},
+		{
+			name:    "replication running within lag",
+			checker: MySQLChecker{CheckReplication: true, MaxReplicationLag: time.Minute},
+			queries: map[string]queryResult{
+				mysqlReplicaStatusQuery: {result: replicaStatusResult("Yes", "Yes", "5")},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"replica_io_running": "Yes", "replica_sql_running": "Yes", "seconds_behind_source": "5"},
+		},
+		{
+			name:    "replication lag over threshold",
+			checker: MySQLChecker{MaxReplicationLag: time.Minute},
+			queries: map[string]queryResult{
+				mysqlReplicaStatusQuery: {result: replicaStatusResult("Yes", "Yes", "120")},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"replica_io_running": "Yes", "replica_sql_running": "Yes", "seconds_behind_source": "120"},
+		},
+		{
+			name:    "sql thread stopped",
+			checker: MySQLChecker{CheckReplication: true},
+			queries: map[string]queryResult{
+				mysqlReplicaStatusQuery: {result: replicaStatusResult("Yes", "No", "")},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"replica_io_running": "Yes", "replica_sql_running": "No", "seconds_behind_source": ""},
+		},
+		{
+			name:    "falls back to slave status",
+			checker: MySQLChecker{CheckReplication: true},
+			queries: map[string]queryResult{
+				mysqlReplicaStatusQuery: {err: errors.New("syntax error")},
+				mysqlSlaveStatusQuery: {result: &database.QueryResult{
+					Columns: []string{"Slave_IO_Running", "Slave_SQL_Running", "Seconds_Behind_Master"},
+					Rows:    [][]string{{"Yes", "Yes", "0"}},
+				}},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"replica_io_running": "Yes", "replica_sql_running": "Yes", "seconds_behind_source": "0"},
+		},
+		{
+			name:    "replication not configured",
+			checker: MySQLChecker{CheckReplication: true},
+			queries: map[string]queryResult{
+				mysqlReplicaStatusQuery: {result: &database.QueryResult{}},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{},
+		},
+		{
+			name:    "read only replica as expected",
+			checker: MySQLChecker{ExpectedRole: "replica"},
+			queries: map[string]queryResult{
+				mysqlReadOnlyQuery: {result: scalarResult("1")},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"read_only": "1", "role": "replica"},
+		},
+		{
+			name:    "writable node when replica expected",
+			checker: MySQLChecker{ExpectedRole: "replica"},
+			queries: map[string]queryResult{
+				mysqlReadOnlyQuery: {result: scalarResult("0")},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"read_only": "0", "role": "primary"},
+		},
+		{
+			name:    "galera cluster synced",
+			checker: MySQLChecker{MinClusterSize: 3},
+			queries: map[string]queryResult{
+				mysqlGaleraQuery: {result: statusResult("wsrep_cluster_size", "3", "wsrep_local_state_comment", "Synced")},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"wsrep_cluster_size": "3", "wsrep_local_state": "Synced"},
+		},
+		{
+			name:    "galera cluster degraded",
+			checker: MySQLChecker{MinClusterSize: 3},
+			queries: map[string]queryResult{
+				mysqlGaleraQuery: {result: statusResult("wsrep_cluster_size", "2", "wsrep_local_state_comment", "Donor/Desynced")},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"wsrep_cluster_size": "2", "wsrep_local_state": "Donor/Desynced"},
+		},
+		{
+			name:    "connections over threshold",
+			checker: MySQLChecker{MaxConnectionsPercent: 80},
+			queries: map[string]queryResult{
+				mysqlThreadsQuery: {result: statusResult("Threads_connected", "140")},
+				mysqlMaxConnQuery: {result: scalarResult("151")},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"threads_connected": "140", "max_connections": "151"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the mock SQLConnection
+			mockConn := new(mockStruct)
+			for query, r := range tc.queries {
+				mockConn.On("Query", mock.Anything, query).Return(r.result, r.err)
+			}
+
+			checker := tc.checker
+			checker.DBConnection = mockConn
+			checker.details = make(map[string]string)
+
+			// Call the method under test
+			err := checker.checkReplication()
+
+			// Assert the result
+			assert.Equal(t, tc.expectErr, err != nil)
+			assert.Equal(t, tc.expectedDetails, checker.Details())
+			mockConn.AssertExpectations(t)
+		})
+	}
+}
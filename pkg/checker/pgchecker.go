@@ -6,23 +6,104 @@ import (
 	"availability-checker/pkg/k8s"
 	"errors"
 	"fmt"
+	"math"
+	"strings"
+	"time"
 
 	_ "github.com/lib/pq"
 )
 
 type PostgresChecker struct {
-	Server             string
-	Port               string
-	DBConnection       database.DBConnection
-	CredentialProvider credentialprovider.CredentialProvider
-	K8sClient          k8s.K8sClient
+	Server string
+	Port   string
+	// Queries are run after a successful ping to verify the database is
+	// actually usable.
+	Queries []QueryAssertion
+	Options ConnectionOptions
+	// ExpectedRole is either "primary" or "replica", empty skips the
+	// pg_is_in_recovery() check.
+	ExpectedRole string
+	// MaxReplicationLag is the maximum replay lag, zero disables the check.
+	MaxReplicationLag time.Duration
+	// MaxConnectionsPercent is the maximum number of connections as a
+	// percentage of max_connections, zero disables the check.
+	MaxConnectionsPercent float64
+	// CheckReplicationSlots fails the check when a replication slot is
+	// inactive.
+	CheckReplicationSlots bool
+	// ReuseConnection keeps the connection pool open between checks
+	// instead of opening and closing it every time.
+	ReuseConnection bool
+	// MaxConsecutiveErrors is the number of failed checks in a row after
+	// which a reused pool is re-created, 3 by default.
+	MaxConsecutiveErrors int
+	DBConnection         database.DBConnection
+	CredentialProvider   credentialprovider.CredentialProvider
+	K8sClient            k8s.K8sClient
+	details              map[string]string
+	session              dbSession
 }
 
 func (c *PostgresChecker) Name() string {
 	return fmt.Sprintf("Postgres: %s:%s", c.Server, c.Port)
 }
 
+func (c *PostgresChecker) Target() string {
+	return c.Server
+}
+
+func (c *PostgresChecker) connectionString(user, pwd string) (string, error) {
+	dbname := c.Options.Database
+	if dbname == "" {
+		dbname = "postgres"
+	}
+	sslmode := c.Options.SSLMode
+	if sslmode == "" {
+		sslmode = "disable"
+	}
+	timeout := 10
+	if c.Options.ConnectTimeout > 0 {
+		// connect_timeout is in whole seconds and 0 waits forever, so
+		// sub-second timeouts are rounded up
+		timeout = int(math.Ceil(c.Options.ConnectTimeout.Seconds()))
+	}
+
+	params := []string{
+		"host=" + quotePgValue(c.Server),
+		"port=" + quotePgValue(c.Port),
+		"dbname=" + quotePgValue(dbname),
+		"user=" + quotePgValue(user),
+		"password=" + quotePgValue(pwd),
+		"sslmode=" + quotePgValue(sslmode),
+		fmt.Sprintf("connect_timeout=%d", timeout),
+	}
+
+	ca, cert, key := c.Options.CACert, c.Options.ClientCert, c.Options.ClientKey
+	if c.Options.usesSecrets() {
+		var err error
+		ca, cert, key, err = c.Options.certificates(c.CredentialProvider)
+		if err != nil {
+			return "", err
+		}
+		params = append(params, "sslinline=true")
+	}
+	if ca != "" {
+		params = append(params, "sslrootcert="+quotePgValue(ca))
+	}
+	if cert != "" {
+		params = append(params, "sslcert="+quotePgValue(cert))
+	}
+	if key != "" {
+		params = append(params, "sslkey="+quotePgValue(key))
+	}
+
+	params = append(params, c.Options.sortedParams(quotePgValue)...)
+	return strings.Join(params, " "), nil
+}
+
 func (c *PostgresChecker) Check() (bool, error) {
+	c.details = make(map[string]string)
+
 	user, pwd, err := c.CredentialProvider.GetCredentials("postgres")
 	if err != nil {
 		return false, fmt.Errorf("error getting credentials: %v", err)
@@ -32,25 +113,48 @@ func (c *PostgresChecker) Check() (bool, error) {
 		return false, errors.New("empty username or password")
 	}
 
-	connectionString := fmt.Sprintf("host=%s port=%s dbname=postgres user=%s password=%s sslmode=disable connect_timeout=10", c.Server, c.Port, user, pwd)
+	connectionString, err := c.connectionString(user, pwd)
+	if err != nil {
+		return false, err
+	}
 
 	fmt.Printf("Connecting to postgres: %s:%s\n", c.Server, c.Port)
-	err = c.DBConnection.Open("postgres", connectionString)
+	release, err := c.session.connect(c.DBConnection, c.ReuseConnection, c.MaxConsecutiveErrors, "postgres", connectionString)
 	if err != nil {
 		fmt.Printf("Error opening connection: %v\n", err)
 		return false, err
 	}
-	defer c.DBConnection.Close()
 
-	err = c.DBConnection.Ping()
+	err = c.verify()
+	release(err)
+	c.session.poolDetails(c.DBConnection, c.details)
 	if err != nil {
-		fmt.Printf("Error pinging database: %v\n", err)
 		return false, err
 	}
 
 	return true, nil
 }
 
+// verify pings the database and runs the configured assertions.
+func (c *PostgresChecker) verify() error {
+	err := c.DBConnection.Ping()
+	if err != nil {
+		fmt.Printf("Error pinging database: %v\n", err)
+		return err
+	}
+
+	err = checkQueries(c.DBConnection, c.Queries)
+	if err != nil {
+		return err
+	}
+
+	return c.checkReplication()
+}
+
+func (c *PostgresChecker) Details() map[string]string {
+	return c.details
+}
+
 func (c *PostgresChecker) Fix() error {
 	return c.K8sClient.ScaleDeploymentToDesiredReplicas("default", "postgres", 1)
 }
@@ -58,3 +162,59 @@ func (c *PostgresChecker) Fix() error {
 func (c *PostgresChecker) IsFixable() bool {
 	return true
 }
+
+// Close closes the reused connection pool, if any.
+func (c *PostgresChecker) Close() error {
+	c.session.shutdown(c.DBConnection)
+	return nil
+}
+
+func init() {
+	Register("postgres", newPostgresChecker)
+}
+
+type postgresConfig struct {
+	Server                string            `yaml:"server"`
+	Port                  string            `yaml:"port"`
+	Queries               []QueryAssertion  `yaml:"queries"`
+	ExpectedRole          string            `yaml:"expectedRole"`
+	MaxReplicationLag     time.Duration     `yaml:"maxReplicationLag"`
+	MaxConnectionsPercent float64           `yaml:"maxConnectionsPercent"`
+	CheckReplicationSlots bool              `yaml:"checkReplicationSlots"`
+	Options               ConnectionOptions `yaml:",inline"`
+	Pool                  poolConfig        `yaml:",inline"`
+}
+
+func newPostgresChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config postgresConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	err = required(map[string]string{"server": config.Server, "port": config.Port})
+	if err != nil {
+		return nil, err
+	}
+	if config.ExpectedRole != "" {
+		err = oneOf("expectedRole", config.ExpectedRole, "primary", "replica")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &PostgresChecker{
+		Server:                config.Server,
+		Port:                  config.Port,
+		Queries:               config.Queries,
+		Options:               config.Options,
+		ExpectedRole:          config.ExpectedRole,
+		MaxReplicationLag:     config.MaxReplicationLag,
+		MaxConnectionsPercent: config.MaxConnectionsPercent,
+		CheckReplicationSlots: config.CheckReplicationSlots,
+		ReuseConnection:       config.Pool.ReuseConnection,
+		MaxConsecutiveErrors:  config.Pool.MaxConsecutiveErrors,
+		DBConnection:          config.Pool.connection(),
+		CredentialProvider:    deps.CredentialProvider,
+		K8sClient:             *deps.K8sClient,
+	}, nil
+}
@@ -0,0 +1,132 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	pgRecoveryQuery      = "SELECT pg_is_in_recovery()"
+	pgReplicaLagQuery    = "SELECT CASE WHEN pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0 ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp()), 0) END"
+	pgPrimaryLagQuery    = "SELECT COALESCE(MAX(EXTRACT(EPOCH FROM replay_lag)), 0) FROM pg_stat_replication"
+	pgConnectionsQuery   = "SELECT count(*), current_setting('max_connections') FROM pg_stat_activity"
+	pgInactiveSlotsQuery = "SELECT slot_name FROM pg_replication_slots WHERE NOT active"
+	pgRolePrimary        = "primary"
+	pgRoleReplica        = "replica"
+)
+
+// checkReplication runs the configured replication and recovery state
+// checks, recording what it finds in the checker details. Every enabled
+// check runs even when a previous one fails.
+func (c *PostgresChecker) checkReplication() error {
+	var failures []string
+
+	if c.ExpectedRole != "" || c.MaxReplicationLag > 0 {
+		role, err := c.role()
+		if err != nil {
+			return err
+		}
+		c.details["role"] = role
+
+		if c.ExpectedRole != "" && role != c.ExpectedRole {
+			failures = append(failures, fmt.Sprintf("expected role %s, got %s", c.ExpectedRole, role))
+		}
+
+		if c.MaxReplicationLag > 0 {
+			lag, err := c.replicationLag(role)
+			if err != nil {
+				return err
+			}
+			c.details["replication_lag"] = lag.String()
+			if lag > c.MaxReplicationLag {
+				failures = append(failures, fmt.Sprintf("replication lag %s exceeds %s", lag, c.MaxReplicationLag))
+			}
+		}
+	}
+
+	if c.MaxConnectionsPercent > 0 {
+		result, err := runQuery(c.DBConnection, pgConnectionsQuery)
+		if err != nil {
+			return fmt.Errorf("error getting connection count: %v", err)
+		}
+		if len(result.Rows) == 0 || len(result.Rows[0]) < 2 {
+			return errors.New("error getting connection count: no rows returned")
+		}
+		connections, err := strconv.Atoi(result.Rows[0][0])
+		if err != nil {
+			return fmt.Errorf("error parsing connection count: %v", err)
+		}
+		max, err := strconv.Atoi(result.Rows[0][1])
+		if err != nil {
+			return fmt.Errorf("error parsing max_connections: %v", err)
+		}
+		c.details["connections"] = strconv.Itoa(connections)
+		c.details["max_connections"] = strconv.Itoa(max)
+
+		percent := float64(connections) / float64(max) * 100
+		if percent > c.MaxConnectionsPercent {
+			failures = append(failures, fmt.Sprintf("connections at %.2f%% of max_connections, exceeds %.2f%%", percent, c.MaxConnectionsPercent))
+		}
+	}
+
+	if c.CheckReplicationSlots {
+		result, err := runQuery(c.DBConnection, pgInactiveSlotsQuery)
+		if err != nil {
+			return fmt.Errorf("error getting replication slots: %v", err)
+		}
+		slots := make([]string, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			slots = append(slots, row[0])
+		}
+		c.details["inactive_slots"] = strings.Join(slots, ",")
+		if len(slots) > 0 {
+			failures = append(failures, fmt.Sprintf("inactive replication slots: %s", strings.Join(slots, ", ")))
+		}
+	}
+
+	if len(failures) > 0 {
+		return errors.New(strings.Join(failures, "; "))
+	}
+
+	return nil
+}
+
+func (c *PostgresChecker) role() (string, error) {
+	result, err := runQuery(c.DBConnection, pgRecoveryQuery)
+	if err != nil {
+		return "", fmt.Errorf("error getting recovery state: %v", err)
+	}
+	inRecovery, err := result.Scalar()
+	if err != nil {
+		return "", fmt.Errorf("error getting recovery state: %v", err)
+	}
+	if inRecovery == "true" {
+		return pgRoleReplica, nil
+	}
+	return pgRolePrimary, nil
+}
+
+// replicationLag returns how far behind the primary this replica is, or for
+// a primary how far behind its slowest replica is.
+func (c *PostgresChecker) replicationLag(role string) (time.Duration, error) {
+	query := pgPrimaryLagQuery
+	if role == pgRoleReplica {
+		query = pgReplicaLagQuery
+	}
+	result, err := runQuery(c.DBConnection, query)
+	if err != nil {
+		return 0, fmt.Errorf("error getting replication lag: %v", err)
+	}
+	value, err := result.Scalar()
+	if err != nil {
+		return 0, fmt.Errorf("error getting replication lag: %v", err)
+	}
+	seconds, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0, fmt.Errorf("error parsing replication lag: %v", err)
+	}
+	return time.Duration(seconds * float64(time.Second)), nil
+}
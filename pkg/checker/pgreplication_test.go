@@ -0,0 +1,151 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/database"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func scalarResult(value string) *database.QueryResult {
+	return &database.QueryResult{Rows: [][]string{{value}}}
+}
+
+func TestPostgresChecker_CheckReplication(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		checker         PostgresChecker
+		queries         map[string]*database.QueryResult
+		expectErr       bool
+		expectedDetails map[string]string
+	}{
+		{
+			name:            "no replication checks",
+			checker:         PostgresChecker{},
+			queries:         map[string]*database.QueryResult{},
+			expectErr:       false,
+			expectedDetails: map[string]string{},
+		},
+		{
+			name:    "primary as expected",
+			checker: PostgresChecker{ExpectedRole: "primary"},
+			queries: map[string]*database.QueryResult{
+				pgRecoveryQuery: scalarResult("false"),
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"role": "primary"},
+		},
+		{
+			name:    "replica when primary expected",
+			checker: PostgresChecker{ExpectedRole: "primary"},
+			queries: map[string]*database.QueryResult{
+				pgRecoveryQuery: scalarResult("true"),
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"role": "replica"},
+		},
+		{
+			name:    "replica lag under threshold",
+			checker: PostgresChecker{ExpectedRole: "replica", MaxReplicationLag: 30 * time.Second},
+			queries: map[string]*database.QueryResult{
+				pgRecoveryQuery:   scalarResult("true"),
+				pgReplicaLagQuery: scalarResult("1.5"),
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"role": "replica", "replication_lag": "1.5s"},
+		},
+		{
+			name:    "primary lag over threshold",
+			checker: PostgresChecker{MaxReplicationLag: 30 * time.Second},
+			queries: map[string]*database.QueryResult{
+				pgRecoveryQuery:   scalarResult("false"),
+				pgPrimaryLagQuery: scalarResult("45"),
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"role": "primary", "replication_lag": "45s"},
+		},
+		{
+			name:    "connections under threshold",
+			checker: PostgresChecker{MaxConnectionsPercent: 80},
+			queries: map[string]*database.QueryResult{
+				pgConnectionsQuery: {Rows: [][]string{{"50", "100"}}},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"connections": "50", "max_connections": "100"},
+		},
+		{
+			name:    "connections over threshold",
+			checker: PostgresChecker{MaxConnectionsPercent: 80},
+			queries: map[string]*database.QueryResult{
+				pgConnectionsQuery: {Rows: [][]string{{"95", "100"}}},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"connections": "95", "max_connections": "100"},
+		},
+		{
+			name:    "no inactive slots",
+			checker: PostgresChecker{CheckReplicationSlots: true},
+			queries: map[string]*database.QueryResult{
+				pgInactiveSlotsQuery: {Columns: []string{"slot_name"}},
+			},
+			expectErr:       false,
+			expectedDetails: map[string]string{"inactive_slots": ""},
+		},
+		{
+			name:    "inactive slots",
+			checker: PostgresChecker{CheckReplicationSlots: true},
+			queries: map[string]*database.QueryResult{
+				pgInactiveSlotsQuery: {Columns: []string{"slot_name"}, Rows: [][]string{{"replica_1"}, {"replica_2"}}},
+			},
+			expectErr:       true,
+			expectedDetails: map[string]string{"inactive_slots": "replica_1,replica_2"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare the mock SQLConnection
+			mockConn := new(mockStruct)
+			for query, result := range tc.queries {
+				mockConn.On("Query", mock.Anything, query).Return(result, nil)
+			}
+
+			checker := tc.checker
+			checker.DBConnection = mockConn
+			checker.details = make(map[string]string)
+
+			// Call the method under test
+			err := checker.checkReplication()
+
+			// Assert the result
+			assert.Equal(t, tc.expectErr, err != nil)
+			assert.Equal(t, tc.expectedDetails, checker.Details())
+			mockConn.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPostgresChecker_CheckReportsDetails(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", mock.Anything).Return(nil)
+	mockConn.On("Ping").Return(nil)
+	mockConn.On("Close").Return(nil)
+	mockConn.On("Query", mock.Anything, pgRecoveryQuery).Return(scalarResult("false"), nil)
+
+	checker := PostgresChecker{
+		Server:             "127.0.0.1",
+		Port:               "5432",
+		ExpectedRole:       "primary",
+		DBConnection:       mockConn,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.True(t, success)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{"role": "primary"}, checker.Details())
+}
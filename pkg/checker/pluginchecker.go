@@ -0,0 +1,121 @@
+package checker
+
+import (
+	"availability-checker/pkg/plugin"
+	"errors"
+	"fmt"
+	"time"
+)
+
+const defaultPluginTimeout = 30 * time.Second
+
+// PluginChecker runs the check and fix commands of an external plugin
+// binary, see the plugin package for the protocol.
+type PluginChecker struct {
+	Plugin plugin.Plugin
+	// CheckerName identifies the checker, it is shown as "<type>: <name>".
+	CheckerName string
+	Config      map[string]interface{}
+	Timeout     time.Duration
+	details     map[string]string
+	message     string
+}
+
+func (c *PluginChecker) Name() string {
+	return fmt.Sprintf("%s: %s", c.Plugin.Type, c.CheckerName)
+}
+
+func (c *PluginChecker) request() plugin.Request {
+	return plugin.Request{Type: c.Plugin.Type, Name: c.CheckerName, Config: c.Config}
+}
+
+func (c *PluginChecker) timeout() time.Duration {
+	if c.Timeout == 0 {
+		return defaultPluginTimeout
+	}
+	return c.Timeout
+}
+
+func (c *PluginChecker) Check() (bool, error) {
+	c.details = nil
+	c.message = ""
+
+	var response plugin.CheckResponse
+	err := plugin.Run(c.Plugin.Path, "check", c.request(), &response, c.timeout())
+	if err != nil {
+		return false, err
+	}
+
+	c.details = response.Details
+	c.message = response.Message
+
+	switch response.Status {
+	case plugin.StatusOK:
+		return true, nil
+	case plugin.StatusDegraded:
+		return false, &DegradedError{Reason: response.Message}
+	case plugin.StatusDown:
+		if response.Message == "" {
+			return false, errors.New("down")
+		}
+		return false, errors.New(response.Message)
+	default:
+		return false, fmt.Errorf("unknown plugin status %q", response.Status)
+	}
+}
+
+func (c *PluginChecker) Details() map[string]string {
+	return c.details
+}
+
+func (c *PluginChecker) Message() string {
+	return c.message
+}
+
+func (c *PluginChecker) Fix() error {
+	var response plugin.FixResponse
+	err := plugin.Run(c.Plugin.Path, "fix", c.request(), &response, c.timeout())
+	if err != nil {
+		return err
+	}
+	if response.Error != "" {
+		return errors.New(response.Error)
+	}
+	return nil
+}
+
+func (c *PluginChecker) IsFixable() bool {
+	return c.Plugin.Fixable
+}
+
+// pluginConfig holds the keys of a plugin checker entry interpreted here,
+// the remaining ones are passed to the plugin as is.
+type pluginConfig struct {
+	Name    string                 `yaml:"name"`
+	Timeout time.Duration          `yaml:"timeout"`
+	Fields  map[string]interface{} `yaml:",inline"`
+}
+
+// PluginFactory returns the factory registered for a checker type provided
+// by a plugin.
+func PluginFactory(p plugin.Plugin) Factory {
+	return func(decode Decoder, deps Dependencies) (Checker, error) {
+		var config pluginConfig
+		err := decode(&config)
+		if err != nil {
+			return nil, err
+		}
+		err = required(map[string]string{"name": config.Name})
+		if err != nil {
+			return nil, err
+		}
+
+		fields, _ := plugin.JSONCompatible(config.Fields).(map[string]interface{})
+		return &PluginChecker{
+			Plugin:      p,
+			CheckerName: config.Name,
+			Config:      fields,
+			Timeout:     config.Timeout,
+		}, nil
+	}
+}
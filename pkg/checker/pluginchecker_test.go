@@ -0,0 +1,163 @@
+package checker
+
+import (
+	"availability-checker/pkg/plugin"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPluginScript = `#!/bin/sh
+case "$1" in
+describe)
+  echo '{"protocolVersion":1,"types":[{"type":"ftp","fixable":true}]}'
+  ;;
+check)
+  input=$(cat)
+  case "$input" in
+  *'"host":"up"'*) echo '{"status":"ok","message":"FTP OK","details":{"latency":"3ms"}}' ;;
+  *'"host":"slow"'*) echo '{"status":"degraded","message":"FTP slow"}' ;;
+  *'"host":"broken"'*) echo 'crashed' >&2; exit 3 ;;
+  *) echo '{"status":"down","message":"connection refused"}' ;;
+  esac
+  ;;
+fix)
+  cat > /dev/null
+  echo '{"error":"restart failed"}'
+  ;;
+esac
+`
+
+func writeTestPlugin(t *testing.T, dir, name, script string, mode os.FileMode) {
+	err := os.WriteFile(filepath.Join(dir, name), []byte(script), mode)
+	assert.Nil(t, err)
+}
+
+func TestPluginDiscover(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "ftp", testPluginScript, 0755)
+	writeTestPlugin(t, dir, "README", "not a plugin", 0644)
+	writeTestPlugin(t, dir, "old", "#!/bin/sh\necho '{\"protocolVersion\":0}'\n", 0755)
+
+	plugins, err := plugin.Discover(dir)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]plugin.Plugin{
+		"ftp": {Path: filepath.Join(dir, "ftp"), Type: "ftp", Fixable: true},
+	}, plugins)
+}
+
+func TestPluginDiscover_MissingDirectory(t *testing.T) {
+	plugins, err := plugin.Discover(filepath.Join(t.TempDir(), "missing"))
+
+	assert.Nil(t, err)
+	assert.Empty(t, plugins)
+}
+
+func TestPluginChecker_Check(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "ftp", testPluginScript, 0755)
+	ftp := plugin.Plugin{Path: filepath.Join(dir, "ftp"), Type: "ftp", Fixable: true}
+
+	// Test cases
+	testCases := []struct {
+		name             string
+		host             string
+		expectedSuccess  bool
+		expectedDegraded bool
+		expectedMessage  string
+		expectedDetails  map[string]string
+	}{
+		{
+			name:            "ok",
+			host:            "up",
+			expectedSuccess: true,
+			expectedMessage: "FTP OK",
+			expectedDetails: map[string]string{"latency": "3ms"},
+		},
+		{
+			name:             "degraded",
+			host:             "slow",
+			expectedSuccess:  false,
+			expectedDegraded: true,
+			expectedMessage:  "FTP slow",
+		},
+		{
+			name:            "down",
+			host:            "down",
+			expectedSuccess: false,
+			expectedMessage: "connection refused",
+		},
+		{
+			name:            "plugin failure",
+			host:            "broken",
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			checker := PluginChecker{
+				Plugin:      ftp,
+				CheckerName: "files",
+				Config:      map[string]interface{}{"host": tc.host},
+			}
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			var degraded *DegradedError
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+			assert.Equal(t, tc.expectedDegraded, errors.As(err, &degraded))
+			assert.Equal(t, tc.expectedMessage, checker.Message())
+			assert.Equal(t, tc.expectedDetails, checker.Details())
+		})
+	}
+}
+
+func TestPluginChecker_CheckTimeoutWithBackgroundChild(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "hang", "#!/bin/sh\nsleep 60 &\nsleep 60\n", 0755)
+	checker := PluginChecker{
+		Plugin:      plugin.Plugin{Path: filepath.Join(dir, "hang"), Type: "hang"},
+		CheckerName: "hang",
+		Timeout:     100 * time.Millisecond,
+	}
+	start := time.Now()
+
+	success, err := checker.Check()
+
+	assert.Less(t, time.Since(start), 5*time.Second)
+	assert.False(t, success)
+	assert.ErrorContains(t, err, "timed out")
+}
+
+func TestPluginChecker_Fix(t *testing.T) {
+	dir := t.TempDir()
+	writeTestPlugin(t, dir, "ftp", testPluginScript, 0755)
+	checker := PluginChecker{Plugin: plugin.Plugin{Path: filepath.Join(dir, "ftp"), Type: "ftp", Fixable: true}, CheckerName: "files"}
+
+	err := checker.Fix()
+
+	assert.EqualError(t, err, "restart failed")
+	assert.True(t, checker.IsFixable())
+	assert.Equal(t, "ftp: files", checker.Name())
+}
+
+func TestJSONCompatible(t *testing.T) {
+	converted := plugin.JSONCompatible(map[interface{}]interface{}{
+		"host":  "ftp.net",
+		"ports": []interface{}{21, map[interface{}]interface{}{"tls": 990}},
+	})
+
+	assert.Equal(t, map[string]interface{}{
+		"host":  "ftp.net",
+		"ports": []interface{}{21, map[string]interface{}{"tls": 990}},
+	}, converted)
+}
@@ -0,0 +1,133 @@
+package checker
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+const defaultConcurrency = 100
+
+// Limits bounds the number of checks running at once: Global in total
+// (100 by default), PerType for each checker type and PerHost against each
+// host, see Targeter. Zero PerType and PerHost limits are unlimited.
+type Limits struct {
+	Global  int            `yaml:"global"`
+	PerType map[string]int `yaml:"perType"`
+	PerHost int            `yaml:"perHost"`
+}
+
+// Validate fails on negative limits and limits of unknown checker types.
+func (l Limits) Validate() error {
+	if l.Global < 0 || l.PerHost < 0 {
+		return errors.New("limits can't be negative")
+	}
+	for checkerType, limit := range l.PerType {
+		if !Registered(checkerType) {
+			return fmt.Errorf("unknown checker type %q", checkerType)
+		}
+		if limit < 0 {
+			return errors.New("limits can't be negative")
+		}
+	}
+	return nil
+}
+
+// Pool runs checks on a bounded number of workers. A check is only handed
+// to a worker once the limits of its type and host allow it, so checks
+// waiting for a busy host don't hold up the others.
+type Pool struct {
+	limits Limits
+	mu     sync.Mutex
+	cond   *sync.Cond
+	// running counts the checks in progress by type and by host
+	runningTypes map[string]int
+	runningHosts map[string]int
+}
+
+func NewPool(limits Limits) *Pool {
+	if limits.Global == 0 {
+		limits.Global = defaultConcurrency
+	}
+	p := &Pool{
+		limits:       limits,
+		runningTypes: make(map[string]int),
+		runningHosts: make(map[string]int),
+	}
+	p.cond = sync.NewCond(&p.mu)
+	return p
+}
+
+// Run calls run for every entry within the limits of the pool and returns
+// once all calls returned. Entries are started in order, except for those
+// that have to wait for their type or host.
+func (p *Pool) Run(entries []Entry, run func(Entry)) {
+	workers := p.limits.Global
+	if workers > len(entries) {
+		workers = len(entries)
+	}
+
+	jobs := make(chan Entry)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for e := range jobs {
+				run(e)
+				p.release(e)
+			}
+		}()
+	}
+
+	pending := append([]Entry(nil), entries...)
+	p.mu.Lock()
+	for len(pending) > 0 {
+		i := p.next(pending)
+		if i < 0 {
+			p.cond.Wait()
+			continue
+		}
+		e := pending[i]
+		pending = append(pending[:i], pending[i+1:]...)
+		p.runningTypes[e.Type]++
+		p.runningHosts[target(e)]++
+		p.mu.Unlock()
+		jobs <- e
+		p.mu.Lock()
+	}
+	p.mu.Unlock()
+
+	close(jobs)
+	wg.Wait()
+}
+
+// next returns the index of the first pending entry allowed to start, or -1
+// when none is. mu must be held.
+func (p *Pool) next(pending []Entry) int {
+	for i, e := range pending {
+		if limit := p.limits.PerType[e.Type]; limit > 0 && p.runningTypes[e.Type] >= limit {
+			continue
+		}
+		if host := target(e); host != "" && p.limits.PerHost > 0 && p.runningHosts[host] >= p.limits.PerHost {
+			continue
+		}
+		return i
+	}
+	return -1
+}
+
+func (p *Pool) release(e Entry) {
+	p.mu.Lock()
+	p.runningTypes[e.Type]--
+	p.runningHosts[target(e)]--
+	p.mu.Unlock()
+	p.cond.Broadcast()
+}
+
+func target(e Entry) string {
+	if t, ok := e.Checker.(Targeter); ok {
+		return t.Target()
+	}
+	return ""
+}
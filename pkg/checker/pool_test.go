@@ -0,0 +1,87 @@
+package checker
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// hostChecker is a checker connecting to host.
+type hostChecker struct {
+	sequenceChecker
+	name string
+	host string
+}
+
+func (c *hostChecker) Name() string   { return c.name }
+func (c *hostChecker) Target() string { return c.host }
+
+// concurrency tracks the checks running at once, in total and by key.
+type concurrency struct {
+	mu      sync.Mutex
+	running map[string]int
+	max     map[string]int
+}
+
+func (c *concurrency) add(key string, delta int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.running[key] += delta
+	if c.running[key] > c.max[key] {
+		c.max[key] = c.running[key]
+	}
+}
+
+func TestPool_Run(t *testing.T) {
+	var entries []Entry
+	for i := 0; i < 20; i++ {
+		entries = append(entries, Entry{Type: "postgres", Checker: &hostChecker{name: fmt.Sprintf("pg-%d", i), host: fmt.Sprintf("db-%d", i%2)}})
+		entries = append(entries, Entry{Type: "http", Checker: &hostChecker{name: fmt.Sprintf("http-%d", i), host: fmt.Sprintf("web-%d", i)}})
+	}
+	pool := NewPool(Limits{Global: 8, PerType: map[string]int{"postgres": 3}, PerHost: 2})
+
+	c := concurrency{running: make(map[string]int), max: make(map[string]int)}
+	var ran []string
+	var mu sync.Mutex
+	pool.Run(entries, func(e Entry) {
+		keys := []string{"global", "type:" + e.Type, "host:" + e.Checker.(Targeter).Target()}
+		for _, key := range keys {
+			c.add(key, 1)
+		}
+		time.Sleep(5 * time.Millisecond)
+		for _, key := range keys {
+			c.add(key, -1)
+		}
+		mu.Lock()
+		ran = append(ran, e.Checker.Name())
+		mu.Unlock()
+	})
+
+	assert.Len(t, ran, len(entries))
+	assert.LessOrEqual(t, c.max["global"], 8)
+	assert.LessOrEqual(t, c.max["type:postgres"], 3)
+	assert.LessOrEqual(t, c.max["host:db-0"], 2)
+	assert.LessOrEqual(t, c.max["host:db-1"], 2)
+	// Checks waiting for postgres slots don't hold up the http ones
+	assert.Greater(t, c.max["type:http"], 3)
+}
+
+func TestPool_RunEmpty(t *testing.T) {
+	NewPool(Limits{}).Run(nil, func(e Entry) { t.Fail() })
+}
+
+func TestLimits_Validate(t *testing.T) {
+	assert.Nil(t, Limits{Global: 10, PerType: map[string]int{"postgres": 2}, PerHost: 1}.Validate())
+	assert.EqualError(t, Limits{PerType: map[string]int{"ftp": 2}}.Validate(), `unknown checker type "ftp"`)
+	assert.EqualError(t, Limits{PerHost: -1}.Validate(), "limits can't be negative")
+}
+
+func TestTarget(t *testing.T) {
+	assert.Equal(t, "example.net", target(Entry{Checker: &HttpChecker{URL: "https://example.net:8443/health"}}))
+	assert.Equal(t, "redis.net", target(Entry{Checker: &RedisChecker{Server: "redis.net", Port: "6379"}}))
+	assert.Equal(t, "", target(Entry{Checker: &RedisChecker{Mode: "cluster", Addrs: []string{"a:6379", "b:6379"}}}))
+	assert.Equal(t, "", target(Entry{Checker: &ExecChecker{Command: "true"}}))
+}
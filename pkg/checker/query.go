@@ -0,0 +1,104 @@
+package checker
+
+import (
+	"availability-checker/pkg/database"
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const defaultQueryTimeout = 10 * time.Second
+
+// QueryAssertion runs a query against a database checker connection and
+// asserts on its result.
+//
+// The "equals", "notEquals", "greaterThan" and "lessThan" operators compare
+// the scalar result (first column of the first row) with Expected, while
+// "rowCount", "minRowCount" and "maxRowCount" compare the number of rows.
+type QueryAssertion struct {
+	Query    string        `yaml:"query"`
+	Operator string        `yaml:"operator"`
+	Expected string        `yaml:"expected"`
+	Timeout  time.Duration `yaml:"timeout,omitempty"`
+}
+
+// checkQueries runs every assertion in order and returns the first failure.
+func checkQueries(conn database.DBConnection, assertions []QueryAssertion) error {
+	for _, a := range assertions {
+		err := a.check(conn)
+		if err != nil {
+			return fmt.Errorf("query %q: %v", a.Query, err)
+		}
+	}
+	return nil
+}
+
+func (a QueryAssertion) check(conn database.DBConnection) error {
+	timeout := a.Timeout
+	if timeout == 0 {
+		timeout = defaultQueryTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	result, err := conn.Query(ctx, a.Query)
+	if err != nil {
+		return err
+	}
+
+	switch a.Operator {
+	case "rowCount", "minRowCount", "maxRowCount":
+		expected, err := strconv.Atoi(a.Expected)
+		if err != nil {
+			return fmt.Errorf("invalid expected row count %q", a.Expected)
+		}
+		count := len(result.Rows)
+		if (a.Operator == "rowCount" && count != expected) ||
+			(a.Operator == "minRowCount" && count < expected) ||
+			(a.Operator == "maxRowCount" && count > expected) {
+			return fmt.Errorf("returned %d rows, expected %s %d", count, a.Operator, expected)
+		}
+		return nil
+	}
+
+	value, err := result.Scalar()
+	if err != nil {
+		return err
+	}
+
+	switch a.Operator {
+	case "", "equals":
+		if value != a.Expected {
+			return fmt.Errorf("returned %q, expected %q", value, a.Expected)
+		}
+	case "notEquals":
+		if value == a.Expected {
+			return fmt.Errorf("returned %q, expected a different value", value)
+		}
+	case "greaterThan", "lessThan":
+		actual, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("returned non numeric value %q", value)
+		}
+		expected, err := strconv.ParseFloat(a.Expected, 64)
+		if err != nil {
+			return fmt.Errorf("invalid expected value %q", a.Expected)
+		}
+		if (a.Operator == "greaterThan" && actual <= expected) ||
+			(a.Operator == "lessThan" && actual >= expected) {
+			return fmt.Errorf("returned %s, expected %s %s", value, a.Operator, a.Expected)
+		}
+	default:
+		return fmt.Errorf("unknown operator %q", a.Operator)
+	}
+
+	return nil
+}
+
+// runQuery runs a query with the default query timeout.
+func runQuery(conn database.DBConnection, query string) (*database.QueryResult, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultQueryTimeout)
+	defer cancel()
+	return conn.Query(ctx, query)
+}
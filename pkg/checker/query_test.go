@@ -0,0 +1,133 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/database"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCheckQueries(t *testing.T) {
+	scalar := &database.QueryResult{Columns: []string{"value"}, Rows: [][]string{{"42"}}}
+	rows := &database.QueryResult{Columns: []string{"id"}, Rows: [][]string{{"1"}, {"2"}, {"3"}}}
+
+	// Test cases
+	testCases := []struct {
+		name      string
+		assertion QueryAssertion
+		result    *database.QueryResult
+		queryErr  error
+		expectErr bool
+	}{
+		{
+			name:      "equals",
+			assertion: QueryAssertion{Operator: "equals", Expected: "42"},
+			result:    scalar,
+			expectErr: false,
+		},
+		{
+			name:      "default operator is equals",
+			assertion: QueryAssertion{Expected: "41"},
+			result:    scalar,
+			expectErr: true,
+		},
+		{
+			name:      "not equals",
+			assertion: QueryAssertion{Operator: "notEquals", Expected: "42"},
+			result:    scalar,
+			expectErr: true,
+		},
+		{
+			name:      "greater than",
+			assertion: QueryAssertion{Operator: "greaterThan", Expected: "10"},
+			result:    scalar,
+			expectErr: false,
+		},
+		{
+			name:      "not greater than",
+			assertion: QueryAssertion{Operator: "greaterThan", Expected: "42"},
+			result:    scalar,
+			expectErr: true,
+		},
+		{
+			name:      "less than",
+			assertion: QueryAssertion{Operator: "lessThan", Expected: "42.5"},
+			result:    scalar,
+			expectErr: false,
+		},
+		{
+			name:      "row count",
+			assertion: QueryAssertion{Operator: "rowCount", Expected: "3"},
+			result:    rows,
+			expectErr: false,
+		},
+		{
+			name:      "min row count",
+			assertion: QueryAssertion{Operator: "minRowCount", Expected: "4"},
+			result:    rows,
+			expectErr: true,
+		},
+		{
+			name:      "max row count",
+			assertion: QueryAssertion{Operator: "maxRowCount", Expected: "3"},
+			result:    rows,
+			expectErr: false,
+		},
+		{
+			name:      "no rows for scalar",
+			assertion: QueryAssertion{Operator: "equals", Expected: "42"},
+			result:    &database.QueryResult{Columns: []string{"value"}},
+			expectErr: true,
+		},
+		{
+			name:      "unknown operator",
+			assertion: QueryAssertion{Operator: "like", Expected: "4%"},
+			result:    scalar,
+			expectErr: true,
+		},
+		{
+			name:      "query error",
+			assertion: QueryAssertion{Operator: "equals", Expected: "42"},
+			queryErr:  errors.New("cannot execute INSERT in a read-only transaction"),
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.assertion.Query = "SELECT 42"
+
+			mockConn := new(mockStruct)
+			mockConn.On("Query", mock.Anything, "SELECT 42").Return(tc.result, tc.queryErr)
+
+			err := checkQueries(mockConn, []QueryAssertion{tc.assertion})
+			assert.Equal(t, tc.expectErr, err != nil)
+
+			mockConn.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPostgresChecker_CheckQueries(t *testing.T) {
+	mockConn := new(mockStruct)
+	mockConn.On("Open", "postgres", mock.Anything).Return(nil)
+	mockConn.On("Ping").Return(nil)
+	mockConn.On("Close").Return(nil)
+	mockConn.On("Query", mock.Anything, "SELECT pg_is_in_recovery()").Return(&database.QueryResult{Rows: [][]string{{"true"}}}, nil)
+
+	checker := PostgresChecker{
+		Server:             "127.0.0.1",
+		Port:               "5432",
+		Queries:            []QueryAssertion{{Query: "SELECT pg_is_in_recovery()", Operator: "equals", Expected: "false"}},
+		DBConnection:       mockConn,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+	mockConn.AssertExpectations(t)
+}
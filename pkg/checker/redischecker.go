@@ -0,0 +1,309 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const defaultRedisTimeout = 10 * time.Second
+
+// RedisChecker pings a standalone, Sentinel managed or Cluster Redis
+// deployment and optionally asserts on its replication role, number of
+// connected replicas and memory usage. In cluster mode the replica and
+// memory assertions apply to every master, and the role can't be asserted.
+// Empty credentials connect without authentication.
+type RedisChecker struct {
+	Server string
+	Port   string
+	// Mode is one of "standalone" (default), "sentinel" or "cluster".
+	Mode string
+	// Addrs lists the sentinel or cluster seed addresses. Server and Port
+	// are used when it is empty.
+	Addrs []string
+	// MasterName is the name of the master monitored by Sentinel.
+	MasterName string
+	DB         int
+	// ExpectedRole is either "master" or "replica", empty skips the check.
+	ExpectedRole         string
+	MinConnectedReplicas int
+	// MaxUsedMemory is the maximum used_memory in bytes, zero disables it.
+	MaxUsedMemory int64
+	// MaxMemoryPercent is the maximum used_memory as a percentage of
+	// maxmemory, zero disables it.
+	MaxMemoryPercent   float64
+	Timeout            time.Duration
+	CredentialProvider credentialprovider.CredentialProvider
+}
+
+func (c *RedisChecker) Name() string {
+	if c.MasterName != "" {
+		return fmt.Sprintf("Redis: %s@%s", c.MasterName, strings.Join(c.addrs(), ","))
+	}
+	return fmt.Sprintf("Redis: %s", strings.Join(c.addrs(), ","))
+}
+
+func (c *RedisChecker) Target() string {
+	if len(c.Addrs) > 0 {
+		return ""
+	}
+	return c.Server
+}
+
+func (c *RedisChecker) addrs() []string {
+	if len(c.Addrs) > 0 {
+		return c.Addrs
+	}
+	return []string{net.JoinHostPort(c.Server, c.Port)}
+}
+
+func (c *RedisChecker) newClient(user, pwd string, timeout time.Duration) (redis.UniversalClient, error) {
+	switch c.Mode {
+	case "", "standalone":
+		return redis.NewClient(&redis.Options{
+			Addr:        c.addrs()[0],
+			Username:    user,
+			Password:    pwd,
+			DB:          c.DB,
+			DialTimeout: timeout,
+		}), nil
+	case "sentinel":
+		if c.MasterName == "" {
+			return nil, errors.New("sentinel mode requires a master name")
+		}
+		return redis.NewFailoverClient(&redis.FailoverOptions{
+			MasterName:       c.MasterName,
+			SentinelAddrs:    c.addrs(),
+			SentinelUsername: user,
+			SentinelPassword: pwd,
+			Username:         user,
+			Password:         pwd,
+			DB:               c.DB,
+			DialTimeout:      timeout,
+		}), nil
+	case "cluster":
+		return redis.NewClusterClient(&redis.ClusterOptions{
+			Addrs:       c.addrs(),
+			Username:    user,
+			Password:    pwd,
+			DialTimeout: timeout,
+		}), nil
+	default:
+		return nil, fmt.Errorf("unknown redis mode %q", c.Mode)
+	}
+}
+
+func (c *RedisChecker) Check() (bool, error) {
+	user, pwd, err := c.CredentialProvider.GetCredentials("redis")
+	if err != nil {
+		return false, fmt.Errorf("error getting credentials: %v", err)
+	}
+
+	timeout := c.Timeout
+	if timeout == 0 {
+		timeout = defaultRedisTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	client, err := c.newClient(user, pwd, timeout)
+	if err != nil {
+		return false, err
+	}
+	defer client.Close()
+
+	err = client.Ping(ctx).Err()
+	if err != nil {
+		return false, err
+	}
+
+	if c.Mode == "cluster" {
+		clusterInfo, err := client.ClusterInfo(ctx).Result()
+		if err != nil {
+			return false, fmt.Errorf("error getting cluster info: %v", err)
+		}
+		if state := parseRedisInfo(clusterInfo)["cluster_state"]; state != "ok" {
+			return false, fmt.Errorf("cluster state is %q", state)
+		}
+	}
+
+	if c.ExpectedRole == "" && c.MinConnectedReplicas == 0 && c.MaxUsedMemory == 0 && c.MaxMemoryPercent == 0 {
+		return true, nil
+	}
+
+	// A cluster client sends INFO to any node, so each master is asked
+	if cluster, ok := client.(*redis.ClusterClient); ok {
+		err = cluster.ForEachMaster(ctx, func(ctx context.Context, node *redis.Client) error {
+			err := c.checkNodeInfo(ctx, node)
+			if err != nil {
+				return fmt.Errorf("master %s: %v", node.Options().Addr, err)
+			}
+			return nil
+		})
+	} else {
+		err = c.checkNodeInfo(ctx, client)
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (c *RedisChecker) checkNodeInfo(ctx context.Context, client redis.Cmdable) error {
+	info, err := client.Info(ctx, "replication", "memory").Result()
+	if err != nil {
+		return fmt.Errorf("error getting info: %v", err)
+	}
+	return c.checkInfo(parseRedisInfo(info))
+}
+
+// checkInfo asserts the configured thresholds against the fields of an
+// INFO reply.
+func (c *RedisChecker) checkInfo(info map[string]string) error {
+	if c.ExpectedRole != "" {
+		role := info["role"]
+		if role == "slave" {
+			role = "replica"
+		}
+		if role != c.ExpectedRole {
+			return fmt.Errorf("expected role %s, got %s", c.ExpectedRole, role)
+		}
+	}
+
+	if c.MinConnectedReplicas > 0 {
+		replicas, err := strconv.Atoi(info["connected_slaves"])
+		if err != nil {
+			return fmt.Errorf("error parsing connected_slaves: %v", err)
+		}
+		if replicas < c.MinConnectedReplicas {
+			return fmt.Errorf("expected at least %d connected replicas, got %d", c.MinConnectedReplicas, replicas)
+		}
+	}
+
+	if c.MaxUsedMemory == 0 && c.MaxMemoryPercent == 0 {
+		return nil
+	}
+
+	used, err := strconv.ParseInt(info["used_memory"], 10, 64)
+	if err != nil {
+		return fmt.Errorf("error parsing used_memory: %v", err)
+	}
+
+	if c.MaxUsedMemory > 0 && used > c.MaxUsedMemory {
+		return fmt.Errorf("used memory %d exceeds %d bytes", used, c.MaxUsedMemory)
+	}
+
+	if c.MaxMemoryPercent > 0 {
+		max, err := strconv.ParseInt(info["maxmemory"], 10, 64)
+		if err != nil {
+			return fmt.Errorf("error parsing maxmemory: %v", err)
+		}
+		if max == 0 {
+			return errors.New("maxmemory is not set, cannot compute memory usage percentage")
+		}
+		percent := float64(used) / float64(max) * 100
+		if percent > c.MaxMemoryPercent {
+			return fmt.Errorf("memory usage %.2f%% exceeds %.2f%%", percent, c.MaxMemoryPercent)
+		}
+	}
+
+	return nil
+}
+
+// parseRedisInfo parses the "field:value" lines of an INFO or CLUSTER INFO
+// reply, skipping section headers.
+func parseRedisInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		key, value, found := strings.Cut(line, ":")
+		if found {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+func (c *RedisChecker) Fix() error {
+	return nil
+}
+
+func (c *RedisChecker) IsFixable() bool {
+	return false
+}
+
+func init() {
+	Register("redis", newRedisChecker)
+}
+
+type redisConfig struct {
+	Server           string        `yaml:"server"`
+	Port             string        `yaml:"port"`
+	Mode             string        `yaml:"mode"`
+	Addrs            []string      `yaml:"addrs"`
+	MasterName       string        `yaml:"masterName"`
+	DB               int           `yaml:"db"`
+	ExpectedRole     string        `yaml:"expectedRole"`
+	MinReplicas      int           `yaml:"minReplicas"`
+	MaxUsedMemory    int64         `yaml:"maxUsedMemory"`
+	MaxMemoryPercent float64       `yaml:"maxMemoryPercent"`
+	Timeout          time.Duration `yaml:"timeout"`
+}
+
+func newRedisChecker(decode Decoder, deps Dependencies) (Checker, error) {
+	var config redisConfig
+	err := decode(&config)
+	if err != nil {
+		return nil, err
+	}
+	switch config.Mode {
+	case "", "standalone":
+		err = required(map[string]string{"server": config.Server, "port": config.Port})
+	case "sentinel":
+		err = required(map[string]string{"addrs": strings.Join(config.Addrs, ","), "masterName": config.MasterName})
+	case "cluster":
+		err = required(map[string]string{"addrs": strings.Join(config.Addrs, ",")})
+	default:
+		err = oneOf("mode", config.Mode, "standalone", "sentinel", "cluster")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if config.ExpectedRole != "" {
+		if config.Mode == "cluster" {
+			return nil, errors.New("expectedRole is not supported in cluster mode")
+		}
+		err = oneOf("expectedRole", config.ExpectedRole, "master", "replica")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &RedisChecker{
+		Server:               config.Server,
+		Port:                 config.Port,
+		Mode:                 config.Mode,
+		Addrs:                config.Addrs,
+		MasterName:           config.MasterName,
+		DB:                   config.DB,
+		ExpectedRole:         config.ExpectedRole,
+		MinConnectedReplicas: config.MinReplicas,
+		MaxUsedMemory:        config.MaxUsedMemory,
+		MaxMemoryPercent:     config.MaxMemoryPercent,
+		Timeout:              config.Timeout,
+		CredentialProvider:   deps.CredentialProvider,
+	}, nil
+}
@@ -0,0 +1,187 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"net"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedisChecker_Check(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name            string
+		user            string
+		pwd             string
+		expectedSuccess bool
+	}{
+		{
+			name:            "valid credentials",
+			user:            "mockuser",
+			pwd:             "mockpassword",
+			expectedSuccess: true,
+		},
+		{
+			name:            "invalid credentials",
+			user:            "mockuser",
+			pwd:             "otherpassword",
+			expectedSuccess: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Prepare a local redis stand-in
+			srv := miniredis.RunT(t)
+			srv.RequireUserAuth(tc.user, tc.pwd)
+			host, port, _ := net.SplitHostPort(srv.Addr())
+
+			// Prepare the checker
+			checker := RedisChecker{
+				Server:             host,
+				Port:               port,
+				CredentialProvider: &credentialprovider.MockCredentialProvider{},
+			}
+
+			// Call the method under test
+			success, err := checker.Check()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedSuccess, err == nil)
+		})
+	}
+}
+
+// noCredentialProvider returns empty credentials, as for a Redis without
+// authentication.
+type noCredentialProvider struct {
+	credentialprovider.MockCredentialProvider
+}
+
+func (c *noCredentialProvider) GetCredentials(checker string) (user, password string, err error) {
+	return "", "", nil
+}
+
+func TestRedisChecker_CheckWithoutAuthentication(t *testing.T) {
+	srv := miniredis.RunT(t)
+	host, port, _ := net.SplitHostPort(srv.Addr())
+
+	checker := RedisChecker{
+		Server:             host,
+		Port:               port,
+		CredentialProvider: &noCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.True(t, success)
+	assert.Nil(t, err)
+}
+
+func TestRedisChecker_CheckUnreachable(t *testing.T) {
+	srv := miniredis.RunT(t)
+	host, port, _ := net.SplitHostPort(srv.Addr())
+	srv.Close()
+
+	checker := RedisChecker{
+		Server:             host,
+		Port:               port,
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+	}
+
+	success, err := checker.Check()
+	assert.False(t, success)
+	assert.NotNil(t, err)
+}
+
+func TestRedisChecker_CheckInfo(t *testing.T) {
+	info := parseRedisInfo("# Replication\r\nrole:master\r\nconnected_slaves:2\r\n\r\n# Memory\r\nused_memory:800\r\nmaxmemory:1000\r\n")
+
+	// Test cases
+	testCases := []struct {
+		name      string
+		checker   RedisChecker
+		expectErr bool
+	}{
+		{
+			name:      "no thresholds",
+			checker:   RedisChecker{},
+			expectErr: false,
+		},
+		{
+			name:      "expected role",
+			checker:   RedisChecker{ExpectedRole: "master"},
+			expectErr: false,
+		},
+		{
+			name:      "unexpected role",
+			checker:   RedisChecker{ExpectedRole: "replica"},
+			expectErr: true,
+		},
+		{
+			name:      "enough replicas",
+			checker:   RedisChecker{MinConnectedReplicas: 2},
+			expectErr: false,
+		},
+		{
+			name:      "not enough replicas",
+			checker:   RedisChecker{MinConnectedReplicas: 3},
+			expectErr: true,
+		},
+		{
+			name:      "used memory under threshold",
+			checker:   RedisChecker{MaxUsedMemory: 900},
+			expectErr: false,
+		},
+		{
+			name:      "used memory over threshold",
+			checker:   RedisChecker{MaxUsedMemory: 700},
+			expectErr: true,
+		},
+		{
+			name:      "memory percent under threshold",
+			checker:   RedisChecker{MaxMemoryPercent: 90},
+			expectErr: false,
+		},
+		{
+			name:      "memory percent over threshold",
+			checker:   RedisChecker{MaxMemoryPercent: 75},
+			expectErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.checker.checkInfo(info)
+			assert.Equal(t, tc.expectErr, err != nil)
+		})
+	}
+}
+
+func TestRedisChecker_CheckInfoReplicaRole(t *testing.T) {
+	checker := RedisChecker{ExpectedRole: "replica"}
+	err := checker.checkInfo(parseRedisInfo("role:slave\r\n"))
+	assert.Nil(t, err)
+}
+
+func TestRedisChecker_Name(t *testing.T) {
+	checker := RedisChecker{
+		Server: "redis.local",
+		Port:   "6379",
+	}
+	assert.Equal(t, "Redis: redis.local:6379", checker.Name())
+
+	checker = RedisChecker{
+		Mode:       "sentinel",
+		MasterName: "mymaster",
+		Addrs:      []string{"s1:26379", "s2:26379"},
+	}
+	assert.Equal(t, "Redis: mymaster@s1:26379,s2:26379", checker.Name())
+}
+
+func TestRedisChecker_IsFixable(t *testing.T) {
+	checker := RedisChecker{}
+	assert.False(t, checker.IsFixable())
+}
@@ -0,0 +1,233 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/k8s"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Dependencies are the shared clients handed to every checker factory.
+type Dependencies struct {
+	CredentialProvider credentialprovider.CredentialProvider
+	K8sClient          *k8s.K8sClient
+}
+
+// Decoder decodes the config entry of a checker into a typed config
+// struct, failing on fields the struct doesn't declare.
+type Decoder func(out interface{}) error
+
+// Entry is a checker built from config.yaml along with the keys every
+// checker type accepts.
+type Entry struct {
+	Checker Checker
+	Type    string
+	// Group and Tags organize the dashboard and filter results.
+	Group string
+	Tags  []string
+	// DependsOn names the checkers this one relies on. While any of them is
+	// unavailable, failures of this checker are reported as blocked.
+	DependsOn []string
+	// Retries is the number of times a failed check is retried, waiting
+	// RetryBackoff before the first retry and twice as long before each
+	// following one.
+	Retries      int
+	RetryBackoff time.Duration
+	// Flaps detects flapping across checks, it is nil when disabled.
+	Flaps *FlapDetector
+	// Config is the entry as written in config.yaml, it tells whether a
+	// checker changed when the config is reloaded.
+	Config map[string]interface{}
+}
+
+// commonConfig holds the keys New interprets for every checker type, the
+// remaining ones are decoded by the factory.
+type commonConfig struct {
+	Group         string        `yaml:"group"`
+	Tags          []string      `yaml:"tags"`
+	DependsOn     []string      `yaml:"dependsOn"`
+	Retries       int           `yaml:"retries"`
+	RetryBackoff  time.Duration `yaml:"retryBackoff"`
+	FlapWindow    int           `yaml:"flapWindow"`
+	FlapThreshold int           `yaml:"flapThreshold"`
+}
+
+var commonKeys = map[string]bool{
+	"group":         true,
+	"tags":          true,
+	"dependsOn":     true,
+	"retries":       true,
+	"retryBackoff":  true,
+	"flapWindow":    true,
+	"flapThreshold": true,
+}
+
+const defaultRetryBackoff = time.Second
+
+func (c commonConfig) validate() error {
+	if c.Retries < 0 {
+		return errors.New("retries can't be negative")
+	}
+	if c.FlapWindow == 0 {
+		if c.FlapThreshold != 0 {
+			return errors.New("flapThreshold requires flapWindow")
+		}
+		return nil
+	}
+	if c.FlapWindow < 2 {
+		return errors.New("flapWindow must be at least 2")
+	}
+	if c.FlapThreshold < 0 || c.FlapThreshold >= c.FlapWindow {
+		return errors.New("flapThreshold must be between 1 and flapWindow - 1")
+	}
+	return nil
+}
+
+// Factory builds a checker from its config entry. Factories are expected to
+// validate the config and fail on missing or invalid fields.
+type Factory func(decode Decoder, deps Dependencies) (Checker, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+// Register makes a checker type available to New. It is meant to be called
+// from the init function of the file implementing the checker and panics
+// when the type is registered twice.
+func Register(checkerType string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+
+	if factory == nil {
+		panic("checker: Register factory is nil for type " + checkerType)
+	}
+	if _, ok := factories[checkerType]; ok {
+		panic("checker: Register called twice for type " + checkerType)
+	}
+	factories[checkerType] = factory
+}
+
+// Registered reports whether a factory is registered for checkerType.
+func Registered(checkerType string) bool {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	_, ok := factories[checkerType]
+	return ok
+}
+
+// Types returns the registered checker types in order.
+func Types() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+
+	types := make([]string, 0, len(factories))
+	for checkerType := range factories {
+		types = append(types, checkerType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// New builds a checker from an entry of the checkers list in config.yaml.
+// The "type" key selects the factory, which decodes the remaining keys
+// except the common ones.
+func New(entry map[string]interface{}, deps Dependencies) (Entry, error) {
+	checkerType, _ := entry["type"].(string)
+	if checkerType == "" {
+		return Entry{}, errors.New("missing checker type")
+	}
+
+	factoriesMu.RLock()
+	factory, ok := factories[checkerType]
+	factoriesMu.RUnlock()
+	if !ok {
+		return Entry{}, fmt.Errorf("unknown checker type %q", checkerType)
+	}
+
+	fields := make(map[string]interface{}, len(entry))
+	common := make(map[string]interface{})
+	for key, value := range entry {
+		switch {
+		case key == "type":
+		case commonKeys[key]:
+			common[key] = value
+		default:
+			fields[key] = value
+		}
+	}
+
+	var config commonConfig
+	err := decodeFields(common, &config)
+	if err == nil {
+		err = config.validate()
+	}
+	if err != nil {
+		return Entry{}, fmt.Errorf("%s checker: %v", checkerType, err)
+	}
+
+	c, err := factory(func(out interface{}) error { return decodeFields(fields, out) }, deps)
+	if err != nil {
+		return Entry{}, fmt.Errorf("%s checker: %v", checkerType, err)
+	}
+	e := Entry{
+		Checker:      c,
+		Type:         checkerType,
+		Group:        config.Group,
+		Tags:         config.Tags,
+		DependsOn:    config.DependsOn,
+		Retries:      config.Retries,
+		RetryBackoff: config.RetryBackoff,
+		Config:       entry,
+	}
+	if e.RetryBackoff == 0 {
+		e.RetryBackoff = defaultRetryBackoff
+	}
+	if config.FlapWindow > 0 {
+		e.Flaps = NewFlapDetector(config.FlapWindow, config.FlapThreshold)
+	}
+	return e, nil
+}
+
+// decodeFields decodes config keys into a typed config struct, failing on
+// fields the struct doesn't declare.
+func decodeFields(fields map[string]interface{}, out interface{}) error {
+	data, err := yaml.Marshal(fields)
+	if err != nil {
+		return err
+	}
+	return yaml.UnmarshalStrict(data, out)
+}
+
+// required fails naming the fields, keyed by their config name, that are
+// empty.
+func required(fields map[string]string) error {
+	var missing []string
+	for name, value := range fields {
+		if value == "" {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	sort.Strings(missing)
+	return fmt.Errorf("missing required field(s): %s", strings.Join(missing, ", "))
+}
+
+// oneOf fails when value is not one of the allowed values.
+func oneOf(name, value string, allowed ...string) error {
+	for _, a := range allowed {
+		if value == a {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid %s %q, expected one of: %s", name, value, strings.Join(allowed, ", "))
+}
@@ -0,0 +1,206 @@
+package checker
+
+import (
+	"availability-checker/pkg/credentialprovider"
+	"availability-checker/pkg/database"
+	"availability-checker/pkg/k8s"
+	"availability-checker/pkg/plugin"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v2"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func testDependencies() Dependencies {
+	return Dependencies{
+		CredentialProvider: &credentialprovider.MockCredentialProvider{},
+		K8sClient:          k8s.NewK8sClientFromClientset(fake.NewSimpleClientset()),
+	}
+}
+
+// newFromYAML builds a checker from a single checkers entry as written in
+// config.yaml.
+func newFromYAML(t *testing.T, entry string) (Checker, error) {
+	e, err := newEntryFromYAML(t, entry)
+	return e.Checker, err
+}
+
+func newEntryFromYAML(t *testing.T, entry string) (Entry, error) {
+	var fields map[string]interface{}
+	err := yaml.Unmarshal([]byte(entry), &fields)
+	assert.Nil(t, err)
+	return New(fields, testDependencies())
+}
+
+func TestNew(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name        string
+		entry       string
+		expectedErr string
+	}{
+		{
+			name:  "http",
+			entry: "type: http\nurl: https://example.net",
+		},
+		{
+			name:  "postgres with options and pool",
+			entry: "type: postgres\nserver: db.net\nport: 5432\nsslMode: require\nreuseConnection: true\nqueries:\n  - query: SELECT 1\n    expected: \"1\"",
+		},
+		{
+			name:  "redis sentinel",
+			entry: "type: redis\nmode: sentinel\naddrs: [a:26379, b:26379]\nmasterName: mymaster",
+		},
+		{
+			name:  "docker by labels",
+			entry: "type: docker\nlabels:\n  app: web",
+		},
+		{
+			name:        "missing type",
+			entry:       "url: https://example.net",
+			expectedErr: "missing checker type",
+		},
+		{
+			name:        "unknown type",
+			entry:       "type: ftp\nserver: ftp.net",
+			expectedErr: `unknown checker type "ftp"`,
+		},
+		{
+			name:        "missing fields",
+			entry:       "type: mysql\ndatabase: app",
+			expectedErr: "mysql checker: missing required field(s): port, server",
+		},
+		{
+			name:        "unknown field",
+			entry:       "type: grpc\nserver: grpc.net\nport: 50051\nservcie: orders",
+			expectedErr: "field servcie not found",
+		},
+		{
+			name:        "invalid value",
+			entry:       "type: kubernetes\nkind: CronJob\nworkload: backup",
+			expectedErr: `kubernetes checker: invalid kind "CronJob", expected one of: Deployment, StatefulSet, DaemonSet`,
+		},
+		{
+			name:        "redis cluster with expected role",
+			entry:       "type: redis\nmode: cluster\naddrs: [a:6379, b:6379]\nexpectedRole: master",
+			expectedErr: "redis checker: expectedRole is not supported in cluster mode",
+		},
+		{
+			name:        "kafka consumer group without topics",
+			entry:       "type: kafka\nbrokers: [kafka-0:9092]\nconsumerGroup: orders",
+			expectedErr: "kafka checker: consumerGroup requires topics",
+		},
+		{
+			name:        "docker without container",
+			entry:       "type: docker\nhost: unix:///var/run/docker.sock",
+			expectedErr: "docker checker: missing required field(s): container or labels",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Call the method under test
+			c, err := newFromYAML(t, tc.entry)
+
+			// Assert the result
+			if tc.expectedErr != "" {
+				assert.ErrorContains(t, err, tc.expectedErr)
+			} else {
+				assert.Nil(t, err)
+				assert.NotNil(t, c)
+			}
+		})
+	}
+}
+
+func TestNew_DecodesTypedConfig(t *testing.T) {
+	c, err := newFromYAML(t, `
+type: postgres
+server: db.net
+port: 5432
+database: app
+connectTimeout: 5s
+maxReplicationLag: 30s
+reuseConnection: true
+maxConsecutiveErrors: 5
+`)
+
+	assert.Nil(t, err)
+	pg := c.(*PostgresChecker)
+	assert.Equal(t, "db.net", pg.Server)
+	assert.Equal(t, "5432", pg.Port)
+	assert.Equal(t, "app", pg.Options.Database)
+	assert.Equal(t, 5*time.Second, pg.Options.ConnectTimeout)
+	assert.Equal(t, 30*time.Second, pg.MaxReplicationLag)
+	assert.True(t, pg.ReuseConnection)
+	assert.Equal(t, 5, pg.MaxConsecutiveErrors)
+	// Reused pools default to two open connections
+	assert.Equal(t, 2, pg.DBConnection.(*database.SQLDBConnection).MaxOpenConns)
+}
+
+func TestNew_CommonKeys(t *testing.T) {
+	e, err := newEntryFromYAML(t, "type: http\nurl: https://example.net/api\ngroup: Web\ntags: [prod, eu]\ndependsOn: [https://example.net]")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.net/api", e.Checker.Name())
+	assert.Equal(t, "http", e.Type)
+	assert.Equal(t, "Web", e.Group)
+	assert.Equal(t, []string{"prod", "eu"}, e.Tags)
+	assert.Equal(t, []string{"https://example.net"}, e.DependsOn)
+	assert.Equal(t, "https://example.net/api", e.Config["url"])
+
+	_, err = newEntryFromYAML(t, "type: http\nurl: https://example.net/api\ndependsOn: https://example.net")
+	assert.ErrorContains(t, err, "http checker: yaml: unmarshal errors")
+}
+
+func TestNew_RetriesAndFlapping(t *testing.T) {
+	e, err := newEntryFromYAML(t, "type: http\nurl: https://example.net")
+	assert.Nil(t, err)
+	assert.Equal(t, 0, e.Retries)
+	assert.Equal(t, time.Second, e.RetryBackoff)
+	assert.Nil(t, e.Flaps)
+
+	e, err = newEntryFromYAML(t, "type: http\nurl: https://example.net\nretries: 3\nretryBackoff: 500ms\nflapWindow: 10")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, e.Retries)
+	assert.Equal(t, 500*time.Millisecond, e.RetryBackoff)
+	assert.Equal(t, &FlapDetector{Window: 10, Threshold: 5}, e.Flaps)
+
+	_, err = newEntryFromYAML(t, "type: http\nurl: https://example.net\nflapWindow: 5\nflapThreshold: 5")
+	assert.EqualError(t, err, "http checker: flapThreshold must be between 1 and flapWindow - 1")
+
+	_, err = newEntryFromYAML(t, "type: http\nurl: https://example.net\nretries: -1")
+	assert.EqualError(t, err, "http checker: retries can't be negative")
+}
+
+func TestNew_PluginFactory(t *testing.T) {
+	Register("test-plugin", PluginFactory(plugin.Plugin{Type: "test-plugin", Path: "/plugins/test"}))
+	defer func() {
+		factoriesMu.Lock()
+		delete(factories, "test-plugin")
+		factoriesMu.Unlock()
+	}()
+
+	c, err := newFromYAML(t, "type: test-plugin\nname: files\ntimeout: 5s\nhost: ftp.net\nports: [21]")
+
+	assert.Nil(t, err)
+	pc := c.(*PluginChecker)
+	assert.Equal(t, "test-plugin: files", pc.Name())
+	assert.Equal(t, 5*time.Second, pc.Timeout)
+	assert.Equal(t, map[string]interface{}{"host": "ftp.net", "ports": []interface{}{21}}, pc.Config)
+
+	_, err = newFromYAML(t, "type: test-plugin\nhost: ftp.net")
+	assert.EqualError(t, err, "test-plugin checker: missing required field(s): name")
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	assert.Panics(t, func() { Register("http", newHttpChecker) })
+}
+
+func TestTypes(t *testing.T) {
+	assert.Equal(t, []string{
+		"amqp", "composite", "docker", "exec", "grpc", "http", "kafka", "kubernetes",
+		"kubernetesCluster", "kubernetesService", "mongodb", "mssql", "mysql", "postgres", "redis",
+	}, Types())
+}
@@ -0,0 +1,25 @@
+package checker
+
+import (
+	"errors"
+	"time"
+)
+
+// sleep waits between retries, it is replaced in tests.
+var sleep = time.Sleep
+
+// CheckWithRetries runs the check of the entry and retries it while it
+// fails, as configured by Retries and RetryBackoff. Degraded results are
+// not retried. It also returns the number of attempts made.
+func (e Entry) CheckWithRetries() (bool, int, error) {
+	backoff := e.RetryBackoff
+	for attempt := 1; ; attempt++ {
+		success, err := e.Checker.Check()
+		var degraded *DegradedError
+		if success || errors.As(err, &degraded) || attempt > e.Retries {
+			return success, attempt, err
+		}
+		sleep(backoff)
+		backoff *= 2
+	}
+}
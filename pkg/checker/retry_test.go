@@ -0,0 +1,94 @@
+package checker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// sequenceChecker returns the given check outcomes in order.
+type sequenceChecker struct {
+	errs  []error
+	calls int
+}
+
+func (c *sequenceChecker) Check() (bool, error) {
+	err := c.errs[c.calls]
+	c.calls++
+	return err == nil, err
+}
+
+func (c *sequenceChecker) Name() string    { return "sequence" }
+func (c *sequenceChecker) Fix() error      { return nil }
+func (c *sequenceChecker) IsFixable() bool { return false }
+
+func TestEntry_CheckWithRetries(t *testing.T) {
+	down := errors.New("down")
+	degraded := &DegradedError{Reason: "slow"}
+
+	// Test cases
+	testCases := []struct {
+		name             string
+		retries          int
+		errs             []error
+		expectedSuccess  bool
+		expectedAttempts int
+		expectedSleeps   []time.Duration
+	}{
+		{
+			name:             "success",
+			retries:          2,
+			errs:             []error{nil},
+			expectedSuccess:  true,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "success after retries",
+			retries:          2,
+			errs:             []error{down, down, nil},
+			expectedSuccess:  true,
+			expectedAttempts: 3,
+			expectedSleeps:   []time.Duration{time.Second, 2 * time.Second},
+		},
+		{
+			name:             "retries exhausted",
+			retries:          1,
+			errs:             []error{down, down, nil},
+			expectedSuccess:  false,
+			expectedAttempts: 2,
+			expectedSleeps:   []time.Duration{time.Second},
+		},
+		{
+			name:             "degraded is not retried",
+			retries:          2,
+			errs:             []error{degraded, nil},
+			expectedSuccess:  false,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "no retries",
+			errs:             []error{down, nil},
+			expectedSuccess:  false,
+			expectedAttempts: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var sleeps []time.Duration
+			sleep = func(d time.Duration) { sleeps = append(sleeps, d) }
+			defer func() { sleep = time.Sleep }()
+			entry := Entry{Checker: &sequenceChecker{errs: tc.errs}, Retries: tc.retries, RetryBackoff: time.Second}
+
+			// Call the method under test
+			success, attempts, _ := entry.CheckWithRetries()
+
+			// Assert the result
+			assert.Equal(t, tc.expectedSuccess, success)
+			assert.Equal(t, tc.expectedAttempts, attempts)
+			assert.Equal(t, tc.expectedSleeps, sleeps)
+		})
+	}
+}
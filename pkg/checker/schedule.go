@@ -0,0 +1,83 @@
+package checker
+
+import (
+	"fmt"
+)
+
+// Schedule groups checkers in levels so that every checker runs after the
+// checkers it depends on or references. Checkers of the same level don't
+// depend on each other and can run concurrently. It fails on references to
+// unknown or ambiguous names and on cycles.
+func Schedule(entries []Entry) ([][]Entry, error) {
+	byName := make(map[string]int, len(entries))
+	duplicates := make(map[string]bool)
+	for i, e := range entries {
+		if _, ok := byName[e.Checker.Name()]; ok {
+			duplicates[e.Checker.Name()] = true
+		}
+		byName[e.Checker.Name()] = i
+	}
+
+	const (
+		visiting  = -1
+		unvisited = 0
+	)
+	// depth holds the level of an entry plus one, so unvisited is zero
+	depth := make([]int, len(entries))
+
+	var visit func(i int) (int, error)
+	visit = func(i int) (int, error) {
+		name := entries[i].Checker.Name()
+		switch depth[i] {
+		case visiting:
+			return 0, fmt.Errorf("dependency cycle through checker %q", name)
+		case unvisited:
+		default:
+			return depth[i], nil
+		}
+
+		depth[i] = visiting
+		level := 0
+		for _, ref := range references(entries[i]) {
+			j, ok := byName[ref]
+			if !ok {
+				return 0, fmt.Errorf("checker %q references unknown checker %q", name, ref)
+			}
+			if duplicates[ref] {
+				return 0, fmt.Errorf("checker %q references ambiguous name %q", name, ref)
+			}
+			refDepth, err := visit(j)
+			if err != nil {
+				return 0, err
+			}
+			if refDepth > level {
+				level = refDepth
+			}
+		}
+		depth[i] = level + 1
+		return depth[i], nil
+	}
+
+	var levels [][]Entry
+	for i, e := range entries {
+		d, err := visit(i)
+		if err != nil {
+			return nil, err
+		}
+		for len(levels) < d {
+			levels = append(levels, nil)
+		}
+		levels[d-1] = append(levels[d-1], e)
+	}
+
+	return levels, nil
+}
+
+// references returns the names of the checkers an entry must run after.
+func references(e Entry) []string {
+	refs := append([]string(nil), e.DependsOn...)
+	if a, ok := e.Checker.(Aggregator); ok {
+		refs = append(refs, a.References()...)
+	}
+	return refs
+}
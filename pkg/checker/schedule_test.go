@@ -0,0 +1,100 @@
+package checker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func entries(checkers ...Checker) []Entry {
+	entries := make([]Entry, len(checkers))
+	for i, c := range checkers {
+		entries[i] = Entry{Checker: c}
+	}
+	return entries
+}
+
+func levelNames(levels [][]Entry) [][]string {
+	names := make([][]string, len(levels))
+	for i, level := range levels {
+		for _, e := range level {
+			names[i] = append(names[i], e.Checker.Name())
+		}
+	}
+	return names
+}
+
+func TestSchedule(t *testing.T) {
+	api := &HttpChecker{URL: "api"}
+	db := &HttpChecker{URL: "db"}
+	redis := &CompositeChecker{CheckerName: "redis", Operator: "or", Checkers: []string{"db"}}
+	payments := &CompositeChecker{CheckerName: "payments", Checkers: []string{"api", "redis"}}
+
+	levels, err := Schedule(entries(payments, api, redis, db))
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"api", "db"}, {"redis"}, {"payments"}}, levelNames(levels))
+}
+
+func TestSchedule_DependsOn(t *testing.T) {
+	levels, err := Schedule([]Entry{
+		{Checker: &HttpChecker{URL: "api"}, DependsOn: []string{"db"}},
+		{Checker: &HttpChecker{URL: "web"}, DependsOn: []string{"api", "db"}},
+		{Checker: &HttpChecker{URL: "db"}},
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, [][]string{{"db"}, {"api"}, {"web"}}, levelNames(levels))
+}
+
+func TestSchedule_Errors(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name        string
+		entries     []Entry
+		expectedErr string
+	}{
+		{
+			name:        "unknown reference",
+			entries:     entries(&CompositeChecker{CheckerName: "payments", Checkers: []string{"api"}}),
+			expectedErr: `checker "payments" references unknown checker "api"`,
+		},
+		{
+			name: "ambiguous reference",
+			entries: entries(
+				&HttpChecker{URL: "api"},
+				&HttpChecker{URL: "api"},
+				&CompositeChecker{CheckerName: "payments", Checkers: []string{"api"}},
+			),
+			expectedErr: `checker "payments" references ambiguous name "api"`,
+		},
+		{
+			name: "cycle",
+			entries: entries(
+				&CompositeChecker{CheckerName: "a", Checkers: []string{"b"}},
+				&CompositeChecker{CheckerName: "b", Checkers: []string{"a"}},
+			),
+			expectedErr: `dependency cycle through checker "a"`,
+		},
+		{
+			name:        "unknown dependency",
+			entries:     []Entry{{Checker: &HttpChecker{URL: "api"}, DependsOn: []string{"db"}}},
+			expectedErr: `checker "api" references unknown checker "db"`,
+		},
+		{
+			name: "dependency cycle",
+			entries: []Entry{
+				{Checker: &HttpChecker{URL: "api"}, DependsOn: []string{"db"}},
+				{Checker: &HttpChecker{URL: "db"}, DependsOn: []string{"api"}},
+			},
+			expectedErr: `dependency cycle through checker "api"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := Schedule(tc.entries)
+			assert.EqualError(t, err, tc.expectedErr)
+		})
+	}
+}
@@ -52,3 +52,18 @@ func (a *AzureKeyVaultCredentialProvider) GetCredentials(checkerType string) (us
 
 	return *userSecretBundle.Value, *passSecretBundle.Value, nil
 }
+
+func (a *AzureKeyVaultCredentialProvider) GetSecret(name string) (string, error) {
+	vaultURL := fmt.Sprintf("https://%s.vault.azure.net", a.vaultName)
+
+	secretBundle, err := a.client.GetSecret(context.TODO(), vaultURL, name, "")
+	if err != nil {
+		return "", err
+	}
+
+	if secretBundle.Value == nil {
+		return "", fmt.Errorf("secret %s has no value", name)
+	}
+
+	return *secretBundle.Value, nil
+}
@@ -3,4 +3,5 @@ package credentialprovider
 type CredentialProvider interface {
 	Authenticate() error
 	GetCredentials(checkerType string) (user, password string, err error)
+	GetSecret(name string) (string, error)
 }
@@ -61,3 +61,26 @@ func (v *HcpVaultCredentialProvider) GetCredentials(checkerType string) (user, p
 
 	return user, pass, nil
 }
+
+func (v *HcpVaultCredentialProvider) GetSecret(name string) (string, error) {
+	secret, err := v.client.Logical().Read(fmt.Sprintf("secret/data/%s", name))
+	if err != nil {
+		return "", err
+	}
+
+	if secret == nil || secret.Data == nil {
+		return "", errors.New("no secret found")
+	}
+
+	data, ok := secret.Data["data"].(map[string]interface{})
+	if !ok {
+		return "", errors.New("malformed secret data")
+	}
+
+	value, ok := data["value"].(string)
+	if !ok {
+		return "", errors.New("value not found in secret data")
+	}
+
+	return value, nil
+}
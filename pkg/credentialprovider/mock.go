@@ -10,3 +10,7 @@ func (c *MockCredentialProvider) Authenticate() error {
 func (c *MockCredentialProvider) GetCredentials(checker string) (user, password string, err error) {
 	return "mockuser", "mockpassword", nil
 }
+
+func (c *MockCredentialProvider) GetSecret(name string) (string, error) {
+	return "mocksecret-" + name, nil
+}
@@ -1,7 +1,14 @@
 package database
 
+import (
+	"context"
+	"database/sql"
+)
+
 type DBConnection interface {
 	Open(driverName, dataSourceName string) error
 	Close() error
 	Ping() error
+	Query(ctx context.Context, query string) (*QueryResult, error)
+	Stats() sql.DBStats
 }
@@ -0,0 +1,32 @@
+package database
+
+import "errors"
+
+// QueryResult holds the columns and rows returned by a query, with every
+// value converted to its string representation. NULL values are returned as
+// empty strings.
+type QueryResult struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Scalar returns the first column of the first row.
+func (r *QueryResult) Scalar() (string, error) {
+	if len(r.Rows) == 0 || len(r.Rows[0]) == 0 {
+		return "", errors.New("query returned no rows")
+	}
+	return r.Rows[0][0], nil
+}
+
+// Value returns the value of the named column in the given row.
+func (r *QueryResult) Value(row int, column string) (string, bool) {
+	if row < 0 || row >= len(r.Rows) {
+		return "", false
+	}
+	for i, name := range r.Columns {
+		if name == column && i < len(r.Rows[row]) {
+			return r.Rows[row][i], true
+		}
+	}
+	return "", false
+}
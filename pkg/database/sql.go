@@ -1,9 +1,18 @@
 package database
 
-import "database/sql"
+import (
+	"context"
+	"database/sql"
+	"time"
+)
 
 type SQLDBConnection struct {
 	*sql.DB
+	// MaxOpenConns, MaxIdleConns and ConnMaxLifetime limit the pool created
+	// by Open, zero keeps the database/sql defaults.
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
 }
 
 func (s *SQLDBConnection) Open(driverName, dataSourceName string) error {
@@ -11,6 +20,15 @@ func (s *SQLDBConnection) Open(driverName, dataSourceName string) error {
 	if err != nil {
 		return err
 	}
+	if s.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(s.MaxOpenConns)
+	}
+	if s.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(s.MaxIdleConns)
+	}
+	if s.ConnMaxLifetime > 0 {
+		db.SetConnMaxLifetime(s.ConnMaxLifetime)
+	}
 	s.DB = db
 	return nil
 }
@@ -22,3 +40,37 @@ func (s *SQLDBConnection) Ping() error {
 func (s *SQLDBConnection) Close() error {
 	return s.DB.Close()
 }
+
+func (s *SQLDBConnection) Query(ctx context.Context, query string) (*QueryResult, error) {
+	rows, err := s.DB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	result := &QueryResult{Columns: columns}
+	for rows.Next() {
+		values := make([]sql.NullString, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		err = rows.Scan(dest...)
+		if err != nil {
+			return nil, err
+		}
+
+		row := make([]string, len(columns))
+		for i, v := range values {
+			row[i] = v.String
+		}
+		result.Rows = append(result.Rows, row)
+	}
+
+	return result, rows.Err()
+}
@@ -0,0 +1,98 @@
+package docker
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+)
+
+// ContainerClient is the subset of the Docker Engine API used by the
+// docker checker.
+type ContainerClient interface {
+	NewClient() error
+	ContainerList(ctx context.Context, opts types.ContainerListOptions) ([]types.Container, error)
+	ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	ContainerRestart(ctx context.Context, containerID string, options container.StopOptions) error
+	Close() error
+}
+
+// EngineClient talks to the Docker Engine API on Host, e.g.
+// unix:///var/run/docker.sock or tcp://10.0.0.5:2375. The DOCKER_HOST
+// environment variable is used when Host is empty. It is safe for
+// concurrent use, as checks and fixes run at the same time.
+type EngineClient struct {
+	Host string
+	mu   sync.Mutex
+	cli  *client.Client
+}
+
+// NewClient creates the underlying client on first use.
+func (c *EngineClient) NewClient() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cli != nil {
+		return nil
+	}
+
+	opts := []client.Opt{client.FromEnv, client.WithAPIVersionNegotiation()}
+	if c.Host != "" {
+		opts = append(opts, client.WithHost(c.Host))
+	}
+	cli, err := client.NewClientWithOpts(opts...)
+	if err != nil {
+		return err
+	}
+
+	c.cli = cli
+	return nil
+}
+
+func (c *EngineClient) client() (*client.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cli == nil {
+		return nil, errors.New("docker client not created")
+	}
+	return c.cli, nil
+}
+
+func (c *EngineClient) ContainerList(ctx context.Context, opts types.ContainerListOptions) ([]types.Container, error) {
+	cli, err := c.client()
+	if err != nil {
+		return nil, err
+	}
+	return cli.ContainerList(ctx, opts)
+}
+
+func (c *EngineClient) ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error) {
+	cli, err := c.client()
+	if err != nil {
+		return types.ContainerJSON{}, err
+	}
+	return cli.ContainerInspect(ctx, containerID)
+}
+
+func (c *EngineClient) ContainerRestart(ctx context.Context, containerID string, options container.StopOptions) error {
+	cli, err := c.client()
+	if err != nil {
+		return err
+	}
+	return cli.ContainerRestart(ctx, containerID, options)
+}
+
+// Close releases the underlying client, a later NewClient creates a new
+// one.
+func (c *EngineClient) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.cli == nil {
+		return nil
+	}
+	err := c.cli.Close()
+	c.cli = nil
+	return err
+}
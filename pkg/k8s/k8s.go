@@ -11,14 +11,19 @@ import (
 
 	"github.com/mitchellh/go-homedir"
 	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 )
 
+// requestTimeout bounds every request made to the API server.
+const requestTimeout = 30 * time.Second
+
 type K8sClient struct {
-	clientset *kubernetes.Clientset
+	clientset kubernetes.Interface
 }
 
 func NewK8sClient() (*K8sClient, error) {
@@ -35,6 +40,10 @@ func NewK8sClient() (*K8sClient, error) {
 		}
 	}
 
+	// Don't let a hung API server block callers without a deadline of
+	// their own, such as Fix
+	config.Timeout = requestTimeout
+
 	// Create a Kubernetes clientset
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
@@ -44,9 +53,15 @@ func NewK8sClient() (*K8sClient, error) {
 	return &K8sClient{clientset: clientset}, nil
 }
 
-func (kc *K8sClient) GetDeployment(namespace, deploymentName string) (*appsv1.Deployment, error) {
+// NewK8sClientFromClientset wraps an existing clientset, such as the fake
+// clientset used in tests.
+func NewK8sClientFromClientset(clientset kubernetes.Interface) *K8sClient {
+	return &K8sClient{clientset: clientset}
+}
+
+func (kc *K8sClient) GetDeployment(ctx context.Context, namespace, deploymentName string) (*appsv1.Deployment, error) {
 	// Get the deployment
-	deployment, err := kc.clientset.AppsV1().Deployments(namespace).Get(context.TODO(), deploymentName, metav1.GetOptions{})
+	deployment, err := kc.clientset.AppsV1().Deployments(namespace).Get(ctx, deploymentName, metav1.GetOptions{})
 	if err != nil {
 		return nil, err
 	}
@@ -56,7 +71,7 @@ func (kc *K8sClient) GetDeployment(namespace, deploymentName string) (*appsv1.De
 
 func (kc *K8sClient) ScaleDeployment(namespace, deploymentName string, replicas int32) error {
 	// Get the deployment
-	deployment, err := kc.GetDeployment(namespace, deploymentName)
+	deployment, err := kc.GetDeployment(context.TODO(), namespace, deploymentName)
 	if err != nil {
 		return err
 	}
@@ -77,7 +92,7 @@ func (kc *K8sClient) ScaleDeployment(namespace, deploymentName string, replicas
 
 func (kc *K8sClient) ScaleDeploymentToZero(namespace, deploymentName string) error {
 	// Get the deployment
-	deployment, err := kc.GetDeployment(namespace, deploymentName)
+	deployment, err := kc.GetDeployment(context.TODO(), namespace, deploymentName)
 	if err != nil {
 		return err
 	}
@@ -153,3 +168,59 @@ func (kc *K8sClient) ScaleDeploymentToDesiredReplicas(namespace, deploymentName
 
 	return nil
 }
+
+func (kc *K8sClient) GetStatefulSet(ctx context.Context, namespace, name string) (*appsv1.StatefulSet, error) {
+	return kc.clientset.AppsV1().StatefulSets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *K8sClient) GetDaemonSet(ctx context.Context, namespace, name string) (*appsv1.DaemonSet, error) {
+	return kc.clientset.AppsV1().DaemonSets(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+func (kc *K8sClient) ListPods(ctx context.Context, namespace, labelSelector string) ([]corev1.Pod, error) {
+	pods, err := kc.clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: labelSelector,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return pods.Items, nil
+}
+
+func (kc *K8sClient) GetService(ctx context.Context, namespace, name string) (*corev1.Service, error) {
+	return kc.clientset.CoreV1().Services(namespace).Get(ctx, name, metav1.GetOptions{})
+}
+
+// ListEndpointSlices returns the EndpointSlices backing a Service.
+func (kc *K8sClient) ListEndpointSlices(ctx context.Context, namespace, serviceName string) ([]discoveryv1.EndpointSlice, error) {
+	slices, err := kc.clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: fmt.Sprintf("%s=%s", discoveryv1.LabelServiceName, serviceName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.Items, nil
+}
+
+func (kc *K8sClient) ListNodes(ctx context.Context) ([]corev1.Node, error) {
+	nodes, err := kc.clientset.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	return nodes.Items, nil
+}
+
+// Readyz queries the API server /readyz endpoint and returns an error
+// when it doesn't report ready.
+func (kc *K8sClient) Readyz(ctx context.Context) error {
+	restClient := kc.clientset.Discovery().RESTClient()
+	if restClient == nil {
+		return errors.New("clientset has no REST client")
+	}
+
+	_, err := restClient.Get().AbsPath("/readyz").DoRaw(ctx)
+	return err
+}
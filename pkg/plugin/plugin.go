@@ -0,0 +1,185 @@
+// Package plugin implements the protocol used to run checkers shipped as
+// external binaries.
+//
+// A plugin is an executable in the plugin directory. It is run with the
+// command as its only argument and exchanges a single JSON document in each
+// direction:
+//
+//	describe: no input, outputs a DescribeResponse listing the checker
+//	          types it provides.
+//	check:    reads a Request, outputs a CheckResponse.
+//	fix:      reads a Request, outputs a FixResponse.
+//
+// A non-zero exit code is treated as an error, with stderr as its message.
+package plugin
+
+import (
+	"availability-checker/pkg/process"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ProtocolVersion is the version of the protocol implemented here, plugins
+// reporting another version are ignored.
+const ProtocolVersion = 1
+
+const describeTimeout = 10 * time.Second
+
+// Check statuses.
+const (
+	StatusOK       = "ok"
+	StatusDegraded = "degraded"
+	StatusDown     = "down"
+)
+
+type TypeDescription struct {
+	Type    string `json:"type"`
+	Fixable bool   `json:"fixable"`
+}
+
+type DescribeResponse struct {
+	ProtocolVersion int               `json:"protocolVersion"`
+	Types           []TypeDescription `json:"types"`
+}
+
+// Request is sent to the check and fix commands. Config holds the checker
+// entry from config.yaml as is.
+type Request struct {
+	Type   string                 `json:"type"`
+	Name   string                 `json:"name"`
+	Config map[string]interface{} `json:"config"`
+}
+
+type CheckResponse struct {
+	Status  string            `json:"status"`
+	Message string            `json:"message,omitempty"`
+	Details map[string]string `json:"details,omitempty"`
+}
+
+type FixResponse struct {
+	Error string `json:"error,omitempty"`
+}
+
+// Plugin is a checker type provided by a plugin binary.
+type Plugin struct {
+	Path    string
+	Type    string
+	Fixable bool
+}
+
+// Discover describes every executable in dir and returns the checker types
+// they provide. A missing directory has no plugins, while plugins that fail
+// to describe themselves are logged and skipped.
+func Discover(dir string) (map[string]Plugin, error) {
+	plugins := make(map[string]Plugin)
+
+	entries, err := os.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return plugins, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range entries {
+		info, err := entry.Info()
+		if err != nil || info.IsDir() || info.Mode()&0111 == 0 {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+
+		var description DescribeResponse
+		err = Run(path, "describe", nil, &description, describeTimeout)
+		if err != nil {
+			log.Printf("Skipping plugin %s: %v\n", path, err)
+			continue
+		}
+		if description.ProtocolVersion != ProtocolVersion {
+			log.Printf("Skipping plugin %s: unsupported protocol version %d\n", path, description.ProtocolVersion)
+			continue
+		}
+
+		for _, t := range description.Types {
+			if existing, ok := plugins[t.Type]; ok {
+				return nil, fmt.Errorf("checker type %s provided by both %s and %s", t.Type, existing.Path, path)
+			}
+			plugins[t.Type] = Plugin{Path: path, Type: t.Type, Fixable: t.Fixable}
+		}
+	}
+
+	return plugins, nil
+}
+
+// Run executes the plugin command, writing request as JSON to its stdin and
+// decoding its stdout into response.
+func Run(path, command string, request, response interface{}, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var stdin, stdout, stderr bytes.Buffer
+	if request != nil {
+		err := json.NewEncoder(&stdin).Encode(request)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %v", err)
+		}
+	}
+
+	cmd := process.Command(ctx, path, command)
+	cmd.Stdin = &stdin
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := process.Run(cmd)
+	if ctx.Err() == context.DeadlineExceeded {
+		return fmt.Errorf("plugin %s timed out after %s", command, timeout)
+	}
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if message == "" {
+			message = err.Error()
+		}
+		return fmt.Errorf("plugin %s failed: %s", command, message)
+	}
+
+	err = json.Unmarshal(stdout.Bytes(), response)
+	if err != nil {
+		return fmt.Errorf("error decoding plugin %s response: %v", command, err)
+	}
+
+	return nil
+}
+
+// JSONCompatible converts the map[interface{}]interface{} values produced
+// by the yaml decoder into map[string]interface{} so they can be encoded as
+// JSON.
+func JSONCompatible(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = JSONCompatible(item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[key] = JSONCompatible(item)
+		}
+		return converted
+	case []interface{}:
+		converted := make([]interface{}, len(v))
+		for i, item := range v {
+			converted[i] = JSONCompatible(item)
+		}
+		return converted
+	default:
+		return v
+	}
+}
@@ -0,0 +1,36 @@
+// Package process runs the external commands of the exec checker and of
+// plugins so that a timeout bounds them, including the processes they
+// start.
+package process
+
+import (
+	"context"
+	"errors"
+	"os/exec"
+	"time"
+)
+
+// waitDelay is how long a command's output is still read after it exits or
+// is killed, in case processes it started keep stdout or stderr open.
+const waitDelay = time.Second
+
+// Command returns a command that runs in its own process group, which is
+// killed as a whole when ctx is done.
+func Command(ctx context.Context, name string, args ...string) *exec.Cmd {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.WaitDelay = waitDelay
+	setProcessGroup(cmd)
+	return cmd
+}
+
+// Run runs a command created by Command. When the command succeeds but
+// processes it started still hold its output open after waitDelay, they are
+// killed and the command is considered successful.
+func Run(cmd *exec.Cmd) error {
+	err := cmd.Run()
+	if errors.Is(err, exec.ErrWaitDelay) {
+		_ = killProcessGroup(cmd)
+		return nil
+	}
+	return err
+}
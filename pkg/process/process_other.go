@@ -0,0 +1,13 @@
+//go:build !unix
+
+package process
+
+import "os/exec"
+
+// Process groups are not available, only the command itself is killed and
+// waitDelay bounds the wait for the processes it started.
+func setProcessGroup(cmd *exec.Cmd) {}
+
+func killProcessGroup(cmd *exec.Cmd) error {
+	return nil
+}
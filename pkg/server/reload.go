@@ -0,0 +1,129 @@
+package server
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"reflect"
+
+	"availability-checker/pkg/checker"
+)
+
+// Reload applies a new config between check cycles. Checkers whose config
+// entry didn't change are kept along with their state, such as connection
+// pools and flapping history, and their latest result. The others are
+// replaced and the ones no longer used closed. An invalid config is
+// rejected, keeping the current one. Reload takes ownership of entries and
+// closes those it doesn't use, including when the config is rejected.
+func (s *Server) Reload(entries []checker.Entry, windows []checker.MaintenanceWindow, limits checker.Limits) error {
+	s.cycleMu.Lock()
+	defer s.cycleMu.Unlock()
+
+	err := s.apply(entries, windows, limits)
+	if err != nil {
+		for _, e := range entries {
+			closeChecker(e.Checker)
+		}
+	}
+	return err
+}
+
+// apply validates the config and swaps it in, it leaves the server
+// untouched when the config is invalid.
+func (s *Server) apply(fresh []checker.Entry, windows []checker.MaintenanceWindow, limits checker.Limits) error {
+	// Reuse the current entries of unchanged checkers
+	current := make(map[string][]int, len(s.entries))
+	for i, e := range s.entries {
+		current[e.Checker.Name()] = append(current[e.Checker.Name()], i)
+	}
+	entries := append([]checker.Entry(nil), fresh...)
+	// reused are the indexes of the current entries kept, replaced those
+	// of the fresh entries they replace
+	reused := make(map[int]bool)
+	replaced := make(map[int]bool)
+	unchanged := make(map[string]bool)
+	var added, modified int
+	for i, e := range entries {
+		candidates, ok := current[e.Checker.Name()]
+		if !ok {
+			added++
+			continue
+		}
+		modified++
+		for _, j := range candidates {
+			if !reused[j] && reflect.DeepEqual(s.entries[j].Config, e.Config) {
+				entries[i] = s.entries[j]
+				reused[j] = true
+				replaced[i] = true
+				unchanged[e.Checker.Name()] = true
+				modified--
+				break
+			}
+		}
+	}
+
+	levels, err := checker.Schedule(entries)
+	if err != nil {
+		return err
+	}
+	err = limits.Validate()
+	if err != nil {
+		return fmt.Errorf("concurrency: %v", err)
+	}
+	for i := range windows {
+		err = windows[i].Validate()
+		if err == nil {
+			err = knownCheckers(entries, windows[i].Checkers)
+		}
+		if err != nil {
+			return fmt.Errorf("maintenance window %q: %v", windows[i].Name, err)
+		}
+	}
+
+	previous := s.entries
+	s.levels = levels
+	s.pool = checker.NewPool(limits)
+
+	s.mu.Lock()
+	s.entries = entries
+	results := make([]checker.CheckResult, 0, len(s.results))
+	for _, r := range s.results {
+		if unchanged[r.Name] {
+			results = append(results, r)
+		}
+	}
+	s.results = results
+	s.mu.Unlock()
+
+	s.silencesMu.Lock()
+	s.windows = windows
+	s.silencesMu.Unlock()
+
+	removed := 0
+	for j, e := range previous {
+		if reused[j] {
+			continue
+		}
+		removed++
+		closeChecker(e.Checker)
+	}
+	removed -= modified
+	for i := range replaced {
+		closeChecker(fresh[i].Checker)
+	}
+
+	log.Printf("Config applied: %d checkers added, %d modified, %d removed, %d unchanged\n", added, modified, removed, len(reused))
+	return nil
+}
+
+// closeChecker releases the resources held by a checker no longer used.
+func closeChecker(c checker.Checker) {
+	closer, ok := c.(io.Closer)
+	if !ok {
+		return
+	}
+	err := closer.Close()
+	if err != nil {
+		log.Printf("Error while closing %s: %s\n", c.Name(), err)
+	}
+}
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"bytes"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"availability-checker/pkg/checker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testChecker is an always available checker recording whether it was
+// fixed and closed.
+type testChecker struct {
+	name   string
+	fixed  bool
+	closed bool
+}
+
+func (c *testChecker) Name() string {
+	return c.name
+}
+
+func (c *testChecker) Check() (bool, error) {
+	return true, nil
+}
+
+func (c *testChecker) Fix() error {
+	c.fixed = true
+	return nil
+}
+
+func (c *testChecker) IsFixable() bool {
+	return true
+}
+
+func (c *testChecker) Close() error {
+	c.closed = true
+	return nil
+}
+
+// testEntry builds an entry for a testChecker whose config entry holds url.
+func testEntry(name, url string) checker.Entry {
+	return checker.Entry{
+		Checker: &testChecker{name: name},
+		Type:    "test",
+		Flaps:   checker.NewFlapDetector(4, 0),
+		Config:  map[string]interface{}{"type": "test", "url": url},
+	}
+}
+
+func closed(e checker.Entry) bool {
+	return e.Checker.(*testChecker).closed
+}
+
+// captureLog returns the log output written while f runs.
+func captureLog(f func()) string {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+	f()
+	return buf.String()
+}
+
+func TestServer_Reload(t *testing.T) {
+	s := &Server{}
+	a, b, c := testEntry("a", "https://a.net"), testEntry("b", "https://b.net"), testEntry("c", "https://c.net")
+	err := s.Reload([]checker.Entry{a, b, c}, nil, checker.Limits{})
+	assert.Nil(t, err)
+	s.results = []checker.CheckResult{{Name: "a", Status: true}, {Name: "b", Status: true}, {Name: "c", Status: false}}
+
+	freshA, modifiedB, d := testEntry("a", "https://a.net"), testEntry("b", "https://b2.net"), testEntry("d", "https://d.net")
+	output := captureLog(func() {
+		err = s.Reload([]checker.Entry{freshA, modifiedB, d}, nil, checker.Limits{})
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, output, "Config applied: 1 checkers added, 1 modified, 1 removed, 1 unchanged")
+
+	// The unchanged checker keeps its entry, flapping history and result
+	assert.Len(t, s.entries, 3)
+	assert.Same(t, a.Checker, s.entries[0].Checker)
+	assert.Same(t, a.Flaps, s.entries[0].Flaps)
+	assert.Same(t, modifiedB.Checker, s.entries[1].Checker)
+	assert.Same(t, d.Checker, s.entries[2].Checker)
+	assert.Equal(t, []checker.CheckResult{{Name: "a", Status: true}}, s.results)
+
+	// The entries no longer used are closed
+	assert.False(t, closed(a))
+	assert.True(t, closed(freshA))
+	assert.True(t, closed(b))
+	assert.False(t, closed(modifiedB))
+	assert.True(t, closed(c))
+	assert.False(t, closed(d))
+}
+
+func TestServer_ReloadRejectsInvalidConfig(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name        string
+		entries     func() []checker.Entry
+		windows     []checker.MaintenanceWindow
+		limits      checker.Limits
+		expectedErr string
+	}{
+		{
+			name: "unknown dependency",
+			entries: func() []checker.Entry {
+				e := testEntry("b", "https://b.net")
+				e.DependsOn = []string{"missing"}
+				e.Config["dependsOn"] = []interface{}{"missing"}
+				return []checker.Entry{testEntry("a", "https://a.net"), e}
+			},
+			expectedErr: `unknown checker "missing"`,
+		},
+		{
+			name: "invalid maintenance schedule",
+			entries: func() []checker.Entry {
+				return []checker.Entry{testEntry("a", "https://a.net"), testEntry("b", "https://b2.net")}
+			},
+			windows: []checker.MaintenanceWindow{{
+				Name:              "nightly",
+				MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"a"}},
+				Schedule:          "0 25 * * *",
+				Duration:          time.Hour,
+			}},
+			expectedErr: `maintenance window "nightly"`,
+		},
+		{
+			name: "maintenance of unknown checker",
+			entries: func() []checker.Entry {
+				return []checker.Entry{testEntry("a", "https://a.net")}
+			},
+			windows: []checker.MaintenanceWindow{{
+				Name:              "upgrade",
+				MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"b"}},
+				Start:             time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+				End:               time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+			}},
+			expectedErr: `maintenance window "upgrade": unknown checker "b"`,
+		},
+		{
+			name: "negative limit",
+			entries: func() []checker.Entry {
+				return []checker.Entry{testEntry("a", "https://a.net")}
+			},
+			limits:      checker.Limits{Global: -1},
+			expectedErr: "concurrency: limits can't be negative",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := &Server{}
+			a, b := testEntry("a", "https://a.net"), testEntry("b", "https://b.net")
+			err := s.Reload([]checker.Entry{a, b}, nil, checker.Limits{})
+			assert.Nil(t, err)
+			results := []checker.CheckResult{{Name: "a", Status: true}, {Name: "b", Status: false}}
+			s.results = results
+			entries, levels, pool := s.entries, s.levels, s.pool
+			fresh := tc.entries()
+
+			// Call the method under test
+			err = s.Reload(fresh, tc.windows, tc.limits)
+
+			// Assert the result
+			assert.ErrorContains(t, err, tc.expectedErr)
+			assert.Equal(t, entries, s.entries)
+			assert.Equal(t, levels, s.levels)
+			assert.Same(t, pool, s.pool)
+			assert.Equal(t, results, s.results)
+			assert.Nil(t, s.windows)
+			assert.False(t, closed(a))
+			assert.False(t, closed(b))
+			for _, e := range fresh {
+				assert.True(t, closed(e), e.Checker.Name())
+			}
+		})
+	}
+}
@@ -1,59 +1,82 @@
 package server
 
 import (
+	"errors"
+	"html/template"
 	"log"
 	"net/http"
+	"net/url"
 	"sort"
+	"strings"
 	"sync"
-	"text/template"
 	"time"
 
 	"availability-checker/pkg/checker"
 )
 
 type Server struct {
-	checkers []checker.Checker
-	results  []checker.CheckResult
+	// cycleMu is held while checks run and while the config is reloaded,
+	// so a reload is applied between check cycles.
+	cycleMu sync.Mutex
+	// levels are the checkers in the order they run, see checker.Schedule
+	levels [][]checker.Entry
+	pool   *checker.Pool
+	// mu guards entries and results, entries are only written on reload
+	// with cycleMu held too.
 	mu       sync.Mutex
+	entries  []checker.Entry
+	results  []checker.CheckResult
 	template *template.Template
+	// silencesMu guards windows and silences. Silences are created through
+	// the API and kept in memory only.
+	silencesMu  sync.Mutex
+	windows     []checker.MaintenanceWindow
+	silences    []checker.Silence
+	lastSilence int
+}
+
+// page is the data rendered by the template.
+type page struct {
+	Groups       []checker.Group
+	Dependencies []checker.DependencyNode
+	// Query holds the filter of the page, States the values it accepts
+	// for status.
+	Query  url.Values
+	States []string
 }
 
-func NewServer(checkers []checker.Checker, templateFile string) *Server {
+// apiResult is a check result as returned by the API.
+type apiResult struct {
+	checker.CheckResult
+	State string `json:"state"`
+}
+
+// NewServer applies the initial config, see Reload.
+func NewServer(entries []checker.Entry, windows []checker.MaintenanceWindow, limits checker.Limits, templateFile string) (*Server, error) {
 	tmpl := template.Must(template.ParseFiles(templateFile))
 
-	return &Server{
-		checkers: checkers,
-		template: tmpl,
+	s := &Server{template: tmpl}
+	err := s.Reload(entries, windows, limits)
+	if err != nil {
+		return nil, err
 	}
+
+	return s, nil
 }
 
 func (s *Server) StartChecking() {
 	for {
-		results := make([]checker.CheckResult, 0, len(s.checkers))
-		var wg sync.WaitGroup
-		resultsCh := make(chan checker.CheckResult)
+		s.cycleMu.Lock()
+		results := make([]checker.CheckResult, 0, len(s.entries))
+		resultsByName := make(map[string]checker.CheckResult, len(s.entries))
 
 		startTime := time.Now()
 
-		for _, c := range s.checkers {
-			wg.Add(1)
-			go func(c checker.Checker) {
-				defer wg.Done()
-				success, err := c.Check()
-				if err != nil {
-					log.Printf("Error while checking %s: %s\n", c.Name(), err)
-				}
-				resultsCh <- checker.CheckResult{Name: c.Name(), Status: success, LastChecked: time.Now(), IsFixable: c.IsFixable()}
-			}(c)
-		}
-
-		go func() {
-			wg.Wait()
-			close(resultsCh)
-		}()
-
-		for r := range resultsCh {
-			results = append(results, r)
+		for _, level := range s.levels {
+			for _, r := range s.runChecks(level, resultsByName) {
+				resultsByName[r.Name] = r
+				results = append(results, r)
+			}
 		}
 
 		// Sort the results by name
@@ -61,7 +84,10 @@ func (s *Server) StartChecking() {
 			return results[i].Name < results[j].Name
 		})
 
+		s.mu.Lock()
 		s.results = results
+		s.mu.Unlock()
+		s.cycleMu.Unlock()
 		duration := time.Since(startTime)
 		log.Printf("All checks completed in %s\n", duration)
 
@@ -69,15 +95,84 @@ func (s *Server) StartChecking() {
 	}
 }
 
+// runChecks runs checkers concurrently within the limits of the pool.
+// Aggregators and dependents get the results of the checkers that ran
+// before them.
+func (s *Server) runChecks(entries []checker.Entry, previous map[string]checker.CheckResult) []checker.CheckResult {
+	results := make([]checker.CheckResult, 0, len(entries))
+	var mu sync.Mutex
+	s.pool.Run(entries, func(e checker.Entry) {
+		result := s.runCheck(e, previous)
+		mu.Lock()
+		results = append(results, result)
+		mu.Unlock()
+	})
+	return results
+}
+
+// runCheck runs a single checker. Failures of checkers whose dependencies
+// are unavailable are reported as blocked rather than logged as errors.
+func (s *Server) runCheck(e checker.Entry, previous map[string]checker.CheckResult) checker.CheckResult {
+	c := e.Checker
+	aggregator, isAggregator := c.(checker.Aggregator)
+	if isAggregator {
+		aggregator.SetResults(previous)
+	}
+	success, attempts, err := e.CheckWithRetries()
+	result := checker.CheckResult{
+		Name:        c.Name(),
+		Type:        e.Type,
+		Group:       e.Group,
+		Tags:        e.Tags,
+		Status:      success,
+		LastChecked: time.Now(),
+		IsFixable:   c.IsFixable(),
+		DependsOn:   e.DependsOn,
+		Attempts:    attempts,
+	}
+	var degraded *checker.DegradedError
+	result.Degraded = errors.As(err, &degraded)
+	if !result.Status && !result.Degraded {
+		result.BlockedBy = checker.BlockedBy(e.DependsOn, previous)
+	}
+	if e.Flaps != nil {
+		wasFlapping := e.Flaps.Flapping()
+		result.Flapping = e.Flaps.Record(result.State())
+		if result.Flapping != wasFlapping {
+			log.Printf("Checker %s flapping: %t, %d state changes in the last %d checks\n", c.Name(), result.Flapping, e.Flaps.Changes(), e.Flaps.Window)
+		}
+	}
+	result.Maintenance = s.maintenance(e, result.LastChecked)
+	switch {
+	case result.Maintenance != "" && err != nil:
+		log.Printf("Check of %s failed during maintenance %s\n", c.Name(), result.Maintenance)
+	case result.Flapping:
+		// Only the start and end of flapping are logged
+	case len(result.BlockedBy) > 0:
+		log.Printf("Check of %s blocked by %s\n", c.Name(), strings.Join(result.BlockedBy, ", "))
+	case err != nil:
+		log.Printf("Error while checking %s: %s\n", c.Name(), err)
+	}
+	if d, ok := c.(checker.Detailer); ok {
+		result.Details = d.Details()
+	}
+	if m, ok := c.(checker.Messager); ok {
+		result.Message = m.Message()
+	}
+	if isAggregator {
+		result.Children = aggregator.Children()
+	}
+	return result
+}
+
 func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	switch r.URL.Path {
 	case "/":
-		s.mu.Lock()
-		defer s.mu.Unlock()
-		err := s.template.Execute(w, s.results)
-		if err != nil {
-			log.Printf("Error while executing template: %s\n", err)
-		}
+		s.dashboard(w, r)
+	case "/api/results":
+		s.apiResults(w, r)
+	case "/api/silences":
+		s.apiSilences(w, r)
 	case "/fix":
 		s.fixChecker(w, r)
 
@@ -86,6 +181,65 @@ func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// filter reads the tag, group, type and status query parameters, each of
+// which can be repeated.
+func filter(query url.Values) (checker.Filter, error) {
+	values := func(key string) []string {
+		var values []string
+		for _, v := range query[key] {
+			if v != "" {
+				values = append(values, v)
+			}
+		}
+		return values
+	}
+	f := checker.Filter{
+		Tags:   values("tag"),
+		Groups: values("group"),
+		Types:  values("type"),
+		States: values("status"),
+	}
+	return f, f.Validate()
+}
+
+func (s *Server) dashboard(w http.ResponseWriter, r *http.Request) {
+	f, err := filter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	err = s.template.Execute(w, page{
+		Groups:       checker.GroupResults(f.Apply(s.results)),
+		Dependencies: checker.DependencyTree(s.results),
+		Query:        r.URL.Query(),
+		States:       checker.States(),
+	})
+	if err != nil {
+		log.Printf("Error while executing template: %s\n", err)
+	}
+}
+
+func (s *Server) apiResults(w http.ResponseWriter, r *http.Request) {
+	f, err := filter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	selected := f.Apply(s.results)
+	s.mu.Unlock()
+
+	results := make([]apiResult, len(selected))
+	for i, result := range selected {
+		results[i] = apiResult{CheckResult: result, State: result.State()}
+	}
+	writeJSON(w, http.StatusOK, results)
+}
+
 func (s *Server) fixChecker(w http.ResponseWriter, r *http.Request) {
 	checkerName := r.URL.Query().Get("checker")
 	if checkerName == "" {
@@ -93,23 +247,32 @@ func (s *Server) fixChecker(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var check checker.Checker
-	for _, c := range s.checkers {
-		if c.Name() == checkerName {
-			check = c
+	var entry *checker.Entry
+	s.mu.Lock()
+	for i := range s.entries {
+		if s.entries[i].Checker.Name() == checkerName {
+			entry = &s.entries[i]
 			break
 		}
 	}
-	if check == nil {
+	s.mu.Unlock()
+	if entry == nil {
 		http.Error(w, "Invalid checker", http.StatusBadRequest)
 		return
 	}
+	check := entry.Checker
 
 	if !check.IsFixable() {
 		http.Error(w, "Checker is not fixable", http.StatusBadRequest)
 		return
 	}
 
+	maintenance := s.maintenance(*entry, time.Now())
+	if maintenance != "" {
+		http.Error(w, "Checker is under maintenance: "+maintenance, http.StatusConflict)
+		return
+	}
+
 	err := check.Fix()
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
@@ -0,0 +1,87 @@
+package server
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"availability-checker/pkg/checker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testDashboard returns a server rendering the dashboard of the repository
+// template with results.
+func testDashboard(t *testing.T, results []checker.CheckResult) *Server {
+	s, err := NewServer([]checker.Entry{testEntry("a", "https://a.net")}, nil, checker.Limits{}, "../../template.gotmpl")
+	assert.Nil(t, err)
+	s.results = results
+	return s
+}
+
+func TestServer_DashboardEscapesQuery(t *testing.T) {
+	const hostile = `"><script>alert(1)</script>`
+
+	// Test cases
+	testCases := []struct {
+		name  string
+		param string
+	}{
+		{name: "group", param: "group"},
+		{name: "tag", param: "tag"},
+		{name: "type", param: "type"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testDashboard(t, nil)
+
+			// Call the method under test
+			response := serve(s, http.MethodGet, "/?"+url.Values{tc.param: {hostile}}.Encode(), "")
+
+			// Assert the result
+			assert.Equal(t, http.StatusOK, response.Code)
+			assert.NotContains(t, response.Body.String(), "<script>alert(1)</script>")
+			assert.Contains(t, response.Body.String(), `value="&#34;&gt;&lt;script&gt;alert(1)&lt;/script&gt;"`)
+		})
+	}
+}
+
+func TestServer_DashboardEscapesCheckOutput(t *testing.T) {
+	// Exec checkers and plugins report whatever their command prints
+	s := testDashboard(t, []checker.CheckResult{{
+		Name:    "Exec: check_web",
+		Message: `CRITICAL - <img src=x onerror=alert(1)>`,
+		Details: map[string]string{"body": "<script>alert(2)</script>"},
+	}})
+
+	response := serve(s, http.MethodGet, "/", "")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.NotContains(t, response.Body.String(), "<img src=x")
+	assert.NotContains(t, response.Body.String(), "<script>alert(2)")
+	assert.Contains(t, response.Body.String(), "CRITICAL - &lt;img src=x onerror=alert(1)&gt;")
+	assert.Contains(t, response.Body.String(), "body: &lt;script&gt;alert(2)&lt;/script&gt;")
+}
+
+func TestServer_DashboardFixButtonOfNamedInstance(t *testing.T) {
+	s := testDashboard(t, []checker.CheckResult{{Name: `MSSQL: db.net\orders`, IsFixable: true}})
+
+	response := serve(s, http.MethodGet, "/", "")
+
+	// The backslash is escaped so JavaScript passes the name unchanged
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Contains(t, response.Body.String(), `onclick="fix('MSSQL: db.net\\orders')"`)
+}
+
+func TestServer_FixNamedInstance(t *testing.T) {
+	s := &Server{}
+	err := s.Reload([]checker.Entry{testEntry(`MSSQL: db.net\orders`, "db.net")}, nil, checker.Limits{})
+	assert.Nil(t, err)
+
+	// As sent by the dashboard with encodeURIComponent
+	response := serve(s, http.MethodPost, "/fix?checker="+url.QueryEscape(`MSSQL: db.net\orders`), "")
+
+	assert.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	assert.True(t, s.entries[0].Checker.(*testChecker).fixed)
+}
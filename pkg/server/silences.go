@@ -0,0 +1,159 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"availability-checker/pkg/checker"
+)
+
+// silenceRequest is the body of a request creating a silence. The silence
+// starts now unless Start is set, and ends at End or after Duration.
+type silenceRequest struct {
+	checker.MaintenanceTarget
+	Comment  string    `json:"comment"`
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Duration string    `json:"duration"`
+}
+
+// maintenance describes the maintenance a checker is under at now, see
+// checker.Maintenance.
+func (s *Server) maintenance(e checker.Entry, now time.Time) string {
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	return checker.Maintenance(e.Checker.Name(), e.Tags, s.windows, s.silences, now)
+}
+
+// knownCheckers fails on names that don't match any of the entries.
+func knownCheckers(entries []checker.Entry, names []string) error {
+	for _, name := range names {
+		found := false
+		for _, e := range entries {
+			if e.Checker.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("unknown checker %q", name)
+		}
+	}
+	return nil
+}
+
+// apiSilences lists silences on GET, creates one from a silenceRequest on
+// POST and removes the one given by the id parameter on DELETE. Expired
+// silences are dropped.
+func (s *Server) apiSilences(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.silencesMu.Lock()
+		s.pruneSilences(time.Now())
+		silences := append([]checker.Silence{}, s.silences...)
+		s.silencesMu.Unlock()
+		writeJSON(w, http.StatusOK, silences)
+	case http.MethodPost:
+		s.createSilence(w, r)
+	case http.MethodDelete:
+		s.deleteSilence(w, r)
+	default:
+		w.Header().Set("Allow", "GET, POST, DELETE")
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *Server) createSilence(w http.ResponseWriter, r *http.Request) {
+	var request silenceRequest
+	decoder := json.NewDecoder(r.Body)
+	decoder.DisallowUnknownFields()
+	err := decoder.Decode(&request)
+	if err != nil {
+		http.Error(w, "Invalid silence: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	now := time.Now()
+	silence := checker.Silence{
+		MaintenanceTarget: request.MaintenanceTarget,
+		Comment:           request.Comment,
+		Start:             request.Start,
+		End:               request.End,
+	}
+	if silence.Start.IsZero() {
+		silence.Start = now
+	}
+	if request.Duration != "" {
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil {
+			http.Error(w, "Invalid silence: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+		silence.End = silence.Start.Add(duration)
+	}
+	err = silence.Validate()
+	if err == nil {
+		s.mu.Lock()
+		err = knownCheckers(s.entries, silence.Checkers)
+		s.mu.Unlock()
+	}
+	if err != nil {
+		http.Error(w, "Invalid silence: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.silencesMu.Lock()
+	s.pruneSilences(now)
+	s.lastSilence++
+	silence.ID = strconv.Itoa(s.lastSilence)
+	s.silences = append(s.silences, silence)
+	s.silencesMu.Unlock()
+
+	log.Printf("Created silence %s until %s\n", silence.ID, silence.End.Format(time.RFC3339))
+	writeJSON(w, http.StatusCreated, silence)
+}
+
+func (s *Server) deleteSilence(w http.ResponseWriter, r *http.Request) {
+	id := r.URL.Query().Get("id")
+	if id == "" {
+		http.Error(w, "Missing id parameter", http.StatusBadRequest)
+		return
+	}
+
+	s.silencesMu.Lock()
+	defer s.silencesMu.Unlock()
+	for i, silence := range s.silences {
+		if silence.ID == id {
+			s.silences = append(s.silences[:i], s.silences[i+1:]...)
+			log.Printf("Deleted silence %s\n", id)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+	}
+	http.Error(w, "Unknown silence", http.StatusNotFound)
+}
+
+// pruneSilences drops the silences that ended before now, silencesMu must
+// be held.
+func (s *Server) pruneSilences(now time.Time) {
+	active := s.silences[:0]
+	for _, silence := range s.silences {
+		if now.Before(silence.End) {
+			active = append(active, silence)
+		}
+	}
+	s.silences = active
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(v)
+	if err != nil {
+		log.Printf("Error while encoding response: %s\n", err)
+	}
+}
@@ -0,0 +1,237 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"availability-checker/pkg/checker"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testServer returns a server checking a and b, b being tagged "db".
+func testServer(t *testing.T) *Server {
+	s := &Server{}
+	b := testEntry("b", "https://b.net")
+	b.Tags = []string{"db"}
+	err := s.Reload([]checker.Entry{testEntry("a", "https://a.net"), b}, nil, checker.Limits{})
+	assert.Nil(t, err)
+	return s
+}
+
+func serve(s *Server, method, target, body string) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+	return recorder
+}
+
+func TestServer_CreateSilence(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name             string
+		body             string
+		expectedStatus   int
+		expectedDuration time.Duration
+	}{
+		{
+			name:             "duration",
+			body:             `{"checkers": ["a"], "comment": "upgrade", "duration": "30m"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedDuration: 30 * time.Minute,
+		},
+		{
+			name:             "start and end",
+			body:             `{"tags": ["db"], "start": "2030-01-01T00:00:00Z", "end": "2030-01-01T02:00:00Z"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedDuration: 2 * time.Hour,
+		},
+		{
+			name:             "duration takes precedence over end",
+			body:             `{"checkers": ["a"], "start": "2030-01-01T00:00:00Z", "end": "2030-01-01T02:00:00Z", "duration": "1h"}`,
+			expectedStatus:   http.StatusCreated,
+			expectedDuration: time.Hour,
+		},
+		{
+			name:           "unknown checker",
+			body:           `{"checkers": ["c"], "duration": "30m"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing target",
+			body:           `{"duration": "30m"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "missing end and duration",
+			body:           `{"checkers": ["a"]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid duration",
+			body:           `{"checkers": ["a"], "duration": "soon"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "end before start",
+			body:           `{"checkers": ["a"], "start": "2030-01-01T02:00:00Z", "end": "2030-01-01T00:00:00Z"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "unknown field",
+			body:           `{"checker": "a", "duration": "30m"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testServer(t)
+
+			// Call the method under test
+			response := serve(s, http.MethodPost, "/api/silences", tc.body)
+
+			// Assert the result
+			assert.Equal(t, tc.expectedStatus, response.Code, response.Body.String())
+			if tc.expectedStatus != http.StatusCreated {
+				assert.Empty(t, s.silences)
+				return
+			}
+			var silence checker.Silence
+			err := json.NewDecoder(response.Body).Decode(&silence)
+			assert.Nil(t, err)
+			assert.Equal(t, "1", silence.ID)
+			assert.Equal(t, tc.expectedDuration, silence.End.Sub(silence.Start))
+			assert.Len(t, s.silences, 1)
+			assert.Equal(t, silence.ID, s.silences[0].ID)
+			assert.True(t, silence.End.Equal(s.silences[0].End))
+		})
+	}
+}
+
+func TestServer_ListSilencesPrunesExpired(t *testing.T) {
+	s := testServer(t)
+	now := time.Now()
+	expired := checker.Silence{ID: "1", MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"a"}}, Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)}
+	active := checker.Silence{ID: "2", MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"a"}}, Start: now.Add(-time.Hour), End: now.Add(time.Hour)}
+	s.silences = []checker.Silence{expired, active}
+
+	response := serve(s, http.MethodGet, "/api/silences", "")
+
+	assert.Equal(t, http.StatusOK, response.Code)
+	var silences []checker.Silence
+	err := json.NewDecoder(response.Body).Decode(&silences)
+	assert.Nil(t, err)
+	assert.Len(t, silences, 1)
+	assert.Equal(t, "2", silences[0].ID)
+	assert.Len(t, s.silences, 1)
+}
+
+func TestServer_DeleteSilence(t *testing.T) {
+	// Test cases
+	testCases := []struct {
+		name             string
+		target           string
+		expectedStatus   int
+		expectedSilences int
+	}{
+		{
+			name:             "existing silence",
+			target:           "/api/silences?id=1",
+			expectedStatus:   http.StatusNoContent,
+			expectedSilences: 0,
+		},
+		{
+			name:             "unknown silence",
+			target:           "/api/silences?id=2",
+			expectedStatus:   http.StatusNotFound,
+			expectedSilences: 1,
+		},
+		{
+			name:             "missing id",
+			target:           "/api/silences",
+			expectedStatus:   http.StatusBadRequest,
+			expectedSilences: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testServer(t)
+			response := serve(s, http.MethodPost, "/api/silences", `{"checkers": ["a"], "duration": "1h"}`)
+			assert.Equal(t, http.StatusCreated, response.Code)
+
+			// Call the method under test
+			response = serve(s, http.MethodDelete, tc.target, "")
+
+			// Assert the result
+			assert.Equal(t, tc.expectedStatus, response.Code)
+			assert.Len(t, s.silences, tc.expectedSilences)
+		})
+	}
+}
+
+func TestServer_FixDuringMaintenance(t *testing.T) {
+	now := time.Now()
+
+	// Test cases
+	testCases := []struct {
+		name           string
+		windows        []checker.MaintenanceWindow
+		silences       []checker.Silence
+		expectedStatus int
+	}{
+		{
+			name:           "no maintenance",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "silenced by tag",
+			silences: []checker.Silence{{
+				ID:                "1",
+				MaintenanceTarget: checker.MaintenanceTarget{Tags: []string{"db"}},
+				Start:             now.Add(-time.Minute),
+				End:               now.Add(time.Hour),
+			}},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "silence of another checker",
+			silences: []checker.Silence{{
+				ID:                "1",
+				MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"a"}},
+				Start:             now.Add(-time.Minute),
+				End:               now.Add(time.Hour),
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "maintenance window",
+			windows: []checker.MaintenanceWindow{{
+				Name:              "upgrade",
+				MaintenanceTarget: checker.MaintenanceTarget{Checkers: []string{"b"}},
+				Start:             now.Add(-time.Minute),
+				End:               now.Add(time.Hour),
+			}},
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := testServer(t)
+			s.windows = tc.windows
+			s.silences = tc.silences
+
+			// Call the method under test
+			response := serve(s, http.MethodPost, "/fix?checker=b", "")
+
+			// Assert the result
+			assert.Equal(t, tc.expectedStatus, response.Code, response.Body.String())
+			assert.Equal(t, tc.expectedStatus == http.StatusOK, s.entries[1].Checker.(*testChecker).fixed)
+		})
+	}
+}